@@ -0,0 +1,357 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v4.24.0
+// source: api/v1/admin.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// FoliumAdminServiceClient is the client API for FoliumAdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type FoliumAdminServiceClient interface {
+	CreateKey(ctx context.Context, in *CreateKeyRequest, opts ...grpc.CallOption) (*CreateKeyResponse, error)
+	UpdateStep(ctx context.Context, in *UpdateStepRequest, opts ...grpc.CallOption) (*UpdateStepResponse, error)
+	UpdateFormat(ctx context.Context, in *UpdateFormatRequest, opts ...grpc.CallOption) (*UpdateFormatResponse, error)
+	GetKey(ctx context.Context, in *GetKeyRequest, opts ...grpc.CallOption) (*GetKeyResponse, error)
+	ListKeys(ctx context.Context, in *ListKeysRequest, opts ...grpc.CallOption) (*ListKeysResponse, error)
+	SetKeyDisabled(ctx context.Context, in *SetKeyDisabledRequest, opts ...grpc.CallOption) (*SetKeyDisabledResponse, error)
+	JumpAhead(ctx context.Context, in *JumpAheadRequest, opts ...grpc.CallOption) (*JumpAheadResponse, error)
+	DecodeId(ctx context.Context, in *DecodeIdRequest, opts ...grpc.CallOption) (*DecodeIdResponse, error)
+}
+
+type foliumAdminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFoliumAdminServiceClient(cc grpc.ClientConnInterface) FoliumAdminServiceClient {
+	return &foliumAdminServiceClient{cc}
+}
+
+func (c *foliumAdminServiceClient) CreateKey(ctx context.Context, in *CreateKeyRequest, opts ...grpc.CallOption) (*CreateKeyResponse, error) {
+	out := new(CreateKeyResponse)
+	err := c.cc.Invoke(ctx, "/folium.api.folium.FoliumAdminService/CreateKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *foliumAdminServiceClient) UpdateStep(ctx context.Context, in *UpdateStepRequest, opts ...grpc.CallOption) (*UpdateStepResponse, error) {
+	out := new(UpdateStepResponse)
+	err := c.cc.Invoke(ctx, "/folium.api.folium.FoliumAdminService/UpdateStep", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *foliumAdminServiceClient) UpdateFormat(ctx context.Context, in *UpdateFormatRequest, opts ...grpc.CallOption) (*UpdateFormatResponse, error) {
+	out := new(UpdateFormatResponse)
+	err := c.cc.Invoke(ctx, "/folium.api.folium.FoliumAdminService/UpdateFormat", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *foliumAdminServiceClient) GetKey(ctx context.Context, in *GetKeyRequest, opts ...grpc.CallOption) (*GetKeyResponse, error) {
+	out := new(GetKeyResponse)
+	err := c.cc.Invoke(ctx, "/folium.api.folium.FoliumAdminService/GetKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *foliumAdminServiceClient) ListKeys(ctx context.Context, in *ListKeysRequest, opts ...grpc.CallOption) (*ListKeysResponse, error) {
+	out := new(ListKeysResponse)
+	err := c.cc.Invoke(ctx, "/folium.api.folium.FoliumAdminService/ListKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *foliumAdminServiceClient) SetKeyDisabled(ctx context.Context, in *SetKeyDisabledRequest, opts ...grpc.CallOption) (*SetKeyDisabledResponse, error) {
+	out := new(SetKeyDisabledResponse)
+	err := c.cc.Invoke(ctx, "/folium.api.folium.FoliumAdminService/SetKeyDisabled", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *foliumAdminServiceClient) JumpAhead(ctx context.Context, in *JumpAheadRequest, opts ...grpc.CallOption) (*JumpAheadResponse, error) {
+	out := new(JumpAheadResponse)
+	err := c.cc.Invoke(ctx, "/folium.api.folium.FoliumAdminService/JumpAhead", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *foliumAdminServiceClient) DecodeId(ctx context.Context, in *DecodeIdRequest, opts ...grpc.CallOption) (*DecodeIdResponse, error) {
+	out := new(DecodeIdResponse)
+	err := c.cc.Invoke(ctx, "/folium.api.folium.FoliumAdminService/DecodeId", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FoliumAdminServiceServer is the server API for FoliumAdminService service.
+// All implementations must embed UnimplementedFoliumAdminServiceServer
+// for forward compatibility
+type FoliumAdminServiceServer interface {
+	CreateKey(context.Context, *CreateKeyRequest) (*CreateKeyResponse, error)
+	UpdateStep(context.Context, *UpdateStepRequest) (*UpdateStepResponse, error)
+	UpdateFormat(context.Context, *UpdateFormatRequest) (*UpdateFormatResponse, error)
+	GetKey(context.Context, *GetKeyRequest) (*GetKeyResponse, error)
+	ListKeys(context.Context, *ListKeysRequest) (*ListKeysResponse, error)
+	SetKeyDisabled(context.Context, *SetKeyDisabledRequest) (*SetKeyDisabledResponse, error)
+	JumpAhead(context.Context, *JumpAheadRequest) (*JumpAheadResponse, error)
+	DecodeId(context.Context, *DecodeIdRequest) (*DecodeIdResponse, error)
+	mustEmbedUnimplementedFoliumAdminServiceServer()
+}
+
+// UnimplementedFoliumAdminServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedFoliumAdminServiceServer struct {
+}
+
+func (UnimplementedFoliumAdminServiceServer) CreateKey(context.Context, *CreateKeyRequest) (*CreateKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateKey not implemented")
+}
+func (UnimplementedFoliumAdminServiceServer) UpdateStep(context.Context, *UpdateStepRequest) (*UpdateStepResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateStep not implemented")
+}
+func (UnimplementedFoliumAdminServiceServer) UpdateFormat(context.Context, *UpdateFormatRequest) (*UpdateFormatResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateFormat not implemented")
+}
+func (UnimplementedFoliumAdminServiceServer) GetKey(context.Context, *GetKeyRequest) (*GetKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetKey not implemented")
+}
+func (UnimplementedFoliumAdminServiceServer) ListKeys(context.Context, *ListKeysRequest) (*ListKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListKeys not implemented")
+}
+func (UnimplementedFoliumAdminServiceServer) SetKeyDisabled(context.Context, *SetKeyDisabledRequest) (*SetKeyDisabledResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetKeyDisabled not implemented")
+}
+func (UnimplementedFoliumAdminServiceServer) JumpAhead(context.Context, *JumpAheadRequest) (*JumpAheadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JumpAhead not implemented")
+}
+func (UnimplementedFoliumAdminServiceServer) DecodeId(context.Context, *DecodeIdRequest) (*DecodeIdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DecodeId not implemented")
+}
+func (UnimplementedFoliumAdminServiceServer) mustEmbedUnimplementedFoliumAdminServiceServer() {}
+
+// UnsafeFoliumAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FoliumAdminServiceServer will
+// result in compilation errors.
+type UnsafeFoliumAdminServiceServer interface {
+	mustEmbedUnimplementedFoliumAdminServiceServer()
+}
+
+func RegisterFoliumAdminServiceServer(s grpc.ServiceRegistrar, srv FoliumAdminServiceServer) {
+	s.RegisterService(&FoliumAdminService_ServiceDesc, srv)
+}
+
+func _FoliumAdminService_CreateKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FoliumAdminServiceServer).CreateKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/folium.api.folium.FoliumAdminService/CreateKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FoliumAdminServiceServer).CreateKey(ctx, req.(*CreateKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FoliumAdminService_UpdateStep_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateStepRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FoliumAdminServiceServer).UpdateStep(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/folium.api.folium.FoliumAdminService/UpdateStep",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FoliumAdminServiceServer).UpdateStep(ctx, req.(*UpdateStepRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FoliumAdminService_UpdateFormat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFormatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FoliumAdminServiceServer).UpdateFormat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/folium.api.folium.FoliumAdminService/UpdateFormat",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FoliumAdminServiceServer).UpdateFormat(ctx, req.(*UpdateFormatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FoliumAdminService_GetKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FoliumAdminServiceServer).GetKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/folium.api.folium.FoliumAdminService/GetKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FoliumAdminServiceServer).GetKey(ctx, req.(*GetKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FoliumAdminService_ListKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FoliumAdminServiceServer).ListKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/folium.api.folium.FoliumAdminService/ListKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FoliumAdminServiceServer).ListKeys(ctx, req.(*ListKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FoliumAdminService_SetKeyDisabled_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetKeyDisabledRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FoliumAdminServiceServer).SetKeyDisabled(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/folium.api.folium.FoliumAdminService/SetKeyDisabled",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FoliumAdminServiceServer).SetKeyDisabled(ctx, req.(*SetKeyDisabledRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FoliumAdminService_JumpAhead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JumpAheadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FoliumAdminServiceServer).JumpAhead(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/folium.api.folium.FoliumAdminService/JumpAhead",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FoliumAdminServiceServer).JumpAhead(ctx, req.(*JumpAheadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FoliumAdminService_DecodeId_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecodeIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FoliumAdminServiceServer).DecodeId(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/folium.api.folium.FoliumAdminService/DecodeId",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FoliumAdminServiceServer).DecodeId(ctx, req.(*DecodeIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FoliumAdminService_ServiceDesc is the grpc.ServiceDesc for FoliumAdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FoliumAdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "folium.api.folium.FoliumAdminService",
+	HandlerType: (*FoliumAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateKey",
+			Handler:    _FoliumAdminService_CreateKey_Handler,
+		},
+		{
+			MethodName: "UpdateStep",
+			Handler:    _FoliumAdminService_UpdateStep_Handler,
+		},
+		{
+			MethodName: "UpdateFormat",
+			Handler:    _FoliumAdminService_UpdateFormat_Handler,
+		},
+		{
+			MethodName: "GetKey",
+			Handler:    _FoliumAdminService_GetKey_Handler,
+		},
+		{
+			MethodName: "ListKeys",
+			Handler:    _FoliumAdminService_ListKeys_Handler,
+		},
+		{
+			MethodName: "SetKeyDisabled",
+			Handler:    _FoliumAdminService_SetKeyDisabled_Handler,
+		},
+		{
+			MethodName: "JumpAhead",
+			Handler:    _FoliumAdminService_JumpAhead_Handler,
+		},
+		{
+			MethodName: "DecodeId",
+			Handler:    _FoliumAdminService_DecodeId_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/v1/admin.proto",
+}
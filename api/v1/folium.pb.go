@@ -130,6 +130,1291 @@ func (x *NextResponse) GetMsg() string {
 	return ""
 }
 
+type NextBatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Count uint32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Step  uint32 `protobuf:"varint,3,opt,name=step,proto3" json:"step,omitempty"`
+}
+
+func (x *NextBatchRequest) Reset() {
+	*x = NextBatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_folium_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NextBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NextBatchRequest) ProtoMessage() {}
+
+func (x *NextBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_folium_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NextBatchRequest.ProtoReflect.Descriptor instead.
+func (*NextBatchRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_folium_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *NextBatchRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *NextBatchRequest) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *NextBatchRequest) GetStep() uint32 {
+	if x != nil {
+		return x.Step
+	}
+	return 0
+}
+
+type NextBatchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ids []uint64 `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	Msg string   `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+}
+
+func (x *NextBatchResponse) Reset() {
+	*x = NextBatchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_folium_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NextBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NextBatchResponse) ProtoMessage() {}
+
+func (x *NextBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_folium_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NextBatchResponse.ProtoReflect.Descriptor instead.
+func (*NextBatchResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_folium_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *NextBatchResponse) GetIds() []uint64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *NextBatchResponse) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+type LeaseRangeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key  string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Size uint32 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+}
+
+func (x *LeaseRangeRequest) Reset() {
+	*x = LeaseRangeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_folium_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LeaseRangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseRangeRequest) ProtoMessage() {}
+
+func (x *LeaseRangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_folium_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseRangeRequest.ProtoReflect.Descriptor instead.
+func (*LeaseRangeRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_folium_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *LeaseRangeRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *LeaseRangeRequest) GetSize() uint32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type LeaseRangeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Begin uint64 `protobuf:"varint,1,opt,name=begin,proto3" json:"begin,omitempty"` // inclusive
+	End   uint64 `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`     // exclusive
+	Msg   string `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
+}
+
+func (x *LeaseRangeResponse) Reset() {
+	*x = LeaseRangeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_folium_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LeaseRangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseRangeResponse) ProtoMessage() {}
+
+func (x *LeaseRangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_folium_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseRangeResponse.ProtoReflect.Descriptor instead.
+func (*LeaseRangeResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_folium_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LeaseRangeResponse) GetBegin() uint64 {
+	if x != nil {
+		return x.Begin
+	}
+	return 0
+}
+
+func (x *LeaseRangeResponse) GetEnd() uint64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *LeaseRangeResponse) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+type NextSnowflakeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *NextSnowflakeRequest) Reset() {
+	*x = NextSnowflakeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_folium_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NextSnowflakeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NextSnowflakeRequest) ProtoMessage() {}
+
+func (x *NextSnowflakeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_folium_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NextSnowflakeRequest.ProtoReflect.Descriptor instead.
+func (*NextSnowflakeRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_folium_proto_rawDescGZIP(), []int{6}
+}
+
+type NextSnowflakeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id  uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Msg string `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+}
+
+func (x *NextSnowflakeResponse) Reset() {
+	*x = NextSnowflakeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_folium_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NextSnowflakeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NextSnowflakeResponse) ProtoMessage() {}
+
+func (x *NextSnowflakeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_folium_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NextSnowflakeResponse.ProtoReflect.Descriptor instead.
+func (*NextSnowflakeResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_folium_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *NextSnowflakeResponse) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *NextSnowflakeResponse) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+type NextSeqRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"` // key created with a reset period
+}
+
+func (x *NextSeqRequest) Reset() {
+	*x = NextSeqRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_folium_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NextSeqRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NextSeqRequest) ProtoMessage() {}
+
+func (x *NextSeqRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_folium_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NextSeqRequest.ProtoReflect.Descriptor instead.
+func (*NextSeqRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_folium_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *NextSeqRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type NextSeqResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Period string `protobuf:"bytes,1,opt,name=period,proto3" json:"period,omitempty"` // like 20261018 for daily and 202610 for monthly reset period
+	Seq    uint64 `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`      // sequence number in the period
+	Msg    string `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
+}
+
+func (x *NextSeqResponse) Reset() {
+	*x = NextSeqResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_folium_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NextSeqResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NextSeqResponse) ProtoMessage() {}
+
+func (x *NextSeqResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_folium_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NextSeqResponse.ProtoReflect.Descriptor instead.
+func (*NextSeqResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_folium_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *NextSeqResponse) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *NextSeqResponse) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *NextSeqResponse) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+type NextFormattedRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"` // key created with a format
+}
+
+func (x *NextFormattedRequest) Reset() {
+	*x = NextFormattedRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_folium_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NextFormattedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NextFormattedRequest) ProtoMessage() {}
+
+func (x *NextFormattedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_folium_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NextFormattedRequest.ProtoReflect.Descriptor instead.
+func (*NextFormattedRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_folium_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *NextFormattedRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type NextFormattedResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`              // raw id, or sequence number in the period for key with reset period
+	Formatted string `protobuf:"bytes,2,opt,name=formatted,proto3" json:"formatted,omitempty"` // id rendered with the format of key
+	Msg       string `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
+}
+
+func (x *NextFormattedResponse) Reset() {
+	*x = NextFormattedResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_folium_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NextFormattedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NextFormattedResponse) ProtoMessage() {}
+
+func (x *NextFormattedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_folium_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NextFormattedResponse.ProtoReflect.Descriptor instead.
+func (*NextFormattedResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_folium_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *NextFormattedResponse) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *NextFormattedResponse) GetFormatted() string {
+	if x != nil {
+		return x.Formatted
+	}
+	return ""
+}
+
+func (x *NextFormattedResponse) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+type NextUUIDv7Request struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *NextUUIDv7Request) Reset() {
+	*x = NextUUIDv7Request{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_folium_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NextUUIDv7Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NextUUIDv7Request) ProtoMessage() {}
+
+func (x *NextUUIDv7Request) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_folium_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NextUUIDv7Request.ProtoReflect.Descriptor instead.
+func (*NextUUIDv7Request) Descriptor() ([]byte, []int) {
+	return file_api_v1_folium_proto_rawDescGZIP(), []int{12}
+}
+
+type NextUUIDv7Response struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uuid string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"` // canonical form like 017f22e2-79b0-7cc3-98c4-dc0c0c07398f
+	Msg  string `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+}
+
+func (x *NextUUIDv7Response) Reset() {
+	*x = NextUUIDv7Response{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_folium_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NextUUIDv7Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NextUUIDv7Response) ProtoMessage() {}
+
+func (x *NextUUIDv7Response) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_folium_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NextUUIDv7Response.ProtoReflect.Descriptor instead.
+func (*NextUUIDv7Response) Descriptor() ([]byte, []int) {
+	return file_api_v1_folium_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *NextUUIDv7Response) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *NextUUIDv7Response) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+type NextUUIDv7BatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Count uint32 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *NextUUIDv7BatchRequest) Reset() {
+	*x = NextUUIDv7BatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_folium_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NextUUIDv7BatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NextUUIDv7BatchRequest) ProtoMessage() {}
+
+func (x *NextUUIDv7BatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_folium_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NextUUIDv7BatchRequest.ProtoReflect.Descriptor instead.
+func (*NextUUIDv7BatchRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_folium_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *NextUUIDv7BatchRequest) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type NextUUIDv7BatchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uuids []string `protobuf:"bytes,1,rep,name=uuids,proto3" json:"uuids,omitempty"` // ascending
+	Msg   string   `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+}
+
+func (x *NextUUIDv7BatchResponse) Reset() {
+	*x = NextUUIDv7BatchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_folium_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NextUUIDv7BatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NextUUIDv7BatchResponse) ProtoMessage() {}
+
+func (x *NextUUIDv7BatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_folium_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NextUUIDv7BatchResponse.ProtoReflect.Descriptor instead.
+func (*NextUUIDv7BatchResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_folium_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *NextUUIDv7BatchResponse) GetUuids() []string {
+	if x != nil {
+		return x.Uuids
+	}
+	return nil
+}
+
+func (x *NextUUIDv7BatchResponse) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+type NextULIDRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *NextULIDRequest) Reset() {
+	*x = NextULIDRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_folium_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NextULIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NextULIDRequest) ProtoMessage() {}
+
+func (x *NextULIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_folium_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NextULIDRequest.ProtoReflect.Descriptor instead.
+func (*NextULIDRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_folium_proto_rawDescGZIP(), []int{16}
+}
+
+type NextULIDResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ulid string `protobuf:"bytes,1,opt,name=ulid,proto3" json:"ulid,omitempty"` // crockford base32 like 01ARYZ6S41TSV4RRFFQ69G5FAV
+	Msg  string `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+}
+
+func (x *NextULIDResponse) Reset() {
+	*x = NextULIDResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_folium_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NextULIDResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NextULIDResponse) ProtoMessage() {}
+
+func (x *NextULIDResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_folium_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NextULIDResponse.ProtoReflect.Descriptor instead.
+func (*NextULIDResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_folium_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *NextULIDResponse) GetUlid() string {
+	if x != nil {
+		return x.Ulid
+	}
+	return ""
+}
+
+func (x *NextULIDResponse) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+type NextULIDBatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Count uint32 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *NextULIDBatchRequest) Reset() {
+	*x = NextULIDBatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_folium_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NextULIDBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NextULIDBatchRequest) ProtoMessage() {}
+
+func (x *NextULIDBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_folium_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NextULIDBatchRequest.ProtoReflect.Descriptor instead.
+func (*NextULIDBatchRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_folium_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *NextULIDBatchRequest) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type NextULIDBatchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ulids []string `protobuf:"bytes,1,rep,name=ulids,proto3" json:"ulids,omitempty"` // ascending
+	Msg   string   `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+}
+
+func (x *NextULIDBatchResponse) Reset() {
+	*x = NextULIDBatchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_folium_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NextULIDBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NextULIDBatchResponse) ProtoMessage() {}
+
+func (x *NextULIDBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_folium_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NextULIDBatchResponse.ProtoReflect.Descriptor instead.
+func (*NextULIDBatchResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_folium_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *NextULIDBatchResponse) GetUlids() []string {
+	if x != nil {
+		return x.Ulids
+	}
+	return nil
+}
+
+func (x *NextULIDBatchResponse) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+type ReserveNumRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`                   // gapless key
+	TtlMs uint32 `protobuf:"varint,2,opt,name=ttl_ms,json=ttlMs,proto3" json:"ttl_ms,omitempty"` // number is given back if it is not confirmed within ttl, 0 means 1 minute
+}
+
+func (x *ReserveNumRequest) Reset() {
+	*x = ReserveNumRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_folium_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReserveNumRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveNumRequest) ProtoMessage() {}
+
+func (x *ReserveNumRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_folium_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveNumRequest.ProtoReflect.Descriptor instead.
+func (*ReserveNumRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_folium_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ReserveNumRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ReserveNumRequest) GetTtlMs() uint32 {
+	if x != nil {
+		return x.TtlMs
+	}
+	return 0
+}
+
+type ReserveNumResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Num      uint64 `protobuf:"varint,1,opt,name=num,proto3" json:"num,omitempty"`
+	Token    string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`                        // required to confirm or release num
+	ExpireAt int64  `protobuf:"varint,3,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"` // unix ms
+	Msg      string `protobuf:"bytes,4,opt,name=msg,proto3" json:"msg,omitempty"`
+}
+
+func (x *ReserveNumResponse) Reset() {
+	*x = ReserveNumResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_folium_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReserveNumResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveNumResponse) ProtoMessage() {}
+
+func (x *ReserveNumResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_folium_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveNumResponse.ProtoReflect.Descriptor instead.
+func (*ReserveNumResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_folium_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ReserveNumResponse) GetNum() uint64 {
+	if x != nil {
+		return x.Num
+	}
+	return 0
+}
+
+func (x *ReserveNumResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ReserveNumResponse) GetExpireAt() int64 {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return 0
+}
+
+func (x *ReserveNumResponse) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+type ConfirmNumRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Num   uint64 `protobuf:"varint,2,opt,name=num,proto3" json:"num,omitempty"`
+	Token string `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+}
+
+func (x *ConfirmNumRequest) Reset() {
+	*x = ConfirmNumRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_folium_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfirmNumRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmNumRequest) ProtoMessage() {}
+
+func (x *ConfirmNumRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_folium_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmNumRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmNumRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_folium_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ConfirmNumRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ConfirmNumRequest) GetNum() uint64 {
+	if x != nil {
+		return x.Num
+	}
+	return 0
+}
+
+func (x *ConfirmNumRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type ConfirmNumResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Msg string `protobuf:"bytes,1,opt,name=msg,proto3" json:"msg,omitempty"`
+}
+
+func (x *ConfirmNumResponse) Reset() {
+	*x = ConfirmNumResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_folium_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfirmNumResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmNumResponse) ProtoMessage() {}
+
+func (x *ConfirmNumResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_folium_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmNumResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmNumResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_folium_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ConfirmNumResponse) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+type ReleaseNumRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Num   uint64 `protobuf:"varint,2,opt,name=num,proto3" json:"num,omitempty"`
+	Token string `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+}
+
+func (x *ReleaseNumRequest) Reset() {
+	*x = ReleaseNumRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_folium_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReleaseNumRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseNumRequest) ProtoMessage() {}
+
+func (x *ReleaseNumRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_folium_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseNumRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseNumRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_folium_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ReleaseNumRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ReleaseNumRequest) GetNum() uint64 {
+	if x != nil {
+		return x.Num
+	}
+	return 0
+}
+
+func (x *ReleaseNumRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type ReleaseNumResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Msg string `protobuf:"bytes,1,opt,name=msg,proto3" json:"msg,omitempty"`
+}
+
+func (x *ReleaseNumResponse) Reset() {
+	*x = ReleaseNumResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_folium_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReleaseNumResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseNumResponse) ProtoMessage() {}
+
+func (x *ReleaseNumResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_folium_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseNumResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseNumResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_folium_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ReleaseNumResponse) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
 type PingRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -139,7 +1424,7 @@ type PingRequest struct {
 func (x *PingRequest) Reset() {
 	*x = PingRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v1_folium_proto_msgTypes[2]
+		mi := &file_api_v1_folium_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -152,7 +1437,7 @@ func (x *PingRequest) String() string {
 func (*PingRequest) ProtoMessage() {}
 
 func (x *PingRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_folium_proto_msgTypes[2]
+	mi := &file_api_v1_folium_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -165,7 +1450,7 @@ func (x *PingRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PingRequest.ProtoReflect.Descriptor instead.
 func (*PingRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_folium_proto_rawDescGZIP(), []int{2}
+	return file_api_v1_folium_proto_rawDescGZIP(), []int{26}
 }
 
 type PingResponse struct {
@@ -177,7 +1462,7 @@ type PingResponse struct {
 func (x *PingResponse) Reset() {
 	*x = PingResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v1_folium_proto_msgTypes[3]
+		mi := &file_api_v1_folium_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -190,7 +1475,7 @@ func (x *PingResponse) String() string {
 func (*PingResponse) ProtoMessage() {}
 
 func (x *PingResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_folium_proto_msgTypes[3]
+	mi := &file_api_v1_folium_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -203,7 +1488,7 @@ func (x *PingResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PingResponse.ProtoReflect.Descriptor instead.
 func (*PingResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_folium_proto_rawDescGZIP(), []int{3}
+	return file_api_v1_folium_proto_rawDescGZIP(), []int{27}
 }
 
 var File_api_v1_folium_proto protoreflect.FileDescriptor
@@ -218,22 +1503,179 @@ var file_api_v1_folium_proto_rawDesc = []byte{
 	0x0c, 0x4e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a,
 	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x10, 0x0a,
 	0x03, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x22,
-	0x0d, 0x0a, 0x0b, 0x50, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x0e,
-	0x0a, 0x0c, 0x50, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0xa1,
-	0x01, 0x0a, 0x0d, 0x46, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x12, 0x47, 0x0a, 0x04, 0x4e, 0x65, 0x78, 0x74, 0x12, 0x1e, 0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75,
-	0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x4e, 0x65, 0x78,
-	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75,
-	0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x4e, 0x65, 0x78,
-	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x04, 0x50, 0x69, 0x6e,
-	0x67, 0x12, 0x1e, 0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x66,
-	0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x50, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x1f, 0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x66,
-	0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x50, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x42, 0x28, 0x5a, 0x26, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2f, 0x72, 0x79, 0x61, 0x6e, 0x72, 0x65, 0x61, 0x64, 0x62, 0x6f, 0x6f, 0x6b, 0x73, 0x2f, 0x66,
-	0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x4e, 0x0a, 0x10, 0x4e, 0x65, 0x78, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73,
+	0x74, 0x65, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x73, 0x74, 0x65, 0x70, 0x22,
+	0x37, 0x0a, 0x11, 0x4e, 0x65, 0x78, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x04, 0x52, 0x03, 0x69, 0x64, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x22, 0x39, 0x0a, 0x11, 0x4c, 0x65, 0x61, 0x73,
+	0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x73,
+	0x69, 0x7a, 0x65, 0x22, 0x4e, 0x0a, 0x12, 0x4c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x61, 0x6e, 0x67,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x65, 0x67,
+	0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x62, 0x65, 0x67, 0x69, 0x6e, 0x12,
+	0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x65, 0x6e,
+	0x64, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6d, 0x73, 0x67, 0x22, 0x16, 0x0a, 0x14, 0x4e, 0x65, 0x78, 0x74, 0x53, 0x6e, 0x6f, 0x77, 0x66,
+	0x6c, 0x61, 0x6b, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x39, 0x0a, 0x15, 0x4e,
+	0x65, 0x78, 0x74, 0x53, 0x6e, 0x6f, 0x77, 0x66, 0x6c, 0x61, 0x6b, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x22, 0x22, 0x0a, 0x0e, 0x4e, 0x65, 0x78, 0x74, 0x53, 0x65,
+	0x71, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x4d, 0x0a, 0x0f, 0x4e, 0x65,
+	0x78, 0x74, 0x53, 0x65, 0x71, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70,
+	0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x22, 0x28, 0x0a, 0x14, 0x4e, 0x65, 0x78,
+	0x74, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x74, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x22, 0x57, 0x0a, 0x15, 0x4e, 0x65, 0x78, 0x74, 0x46, 0x6f, 0x72, 0x6d, 0x61,
+	0x74, 0x74, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x09,
+	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x74, 0x65, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x73,
+	0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x22, 0x13, 0x0a, 0x11,
+	0x4e, 0x65, 0x78, 0x74, 0x55, 0x55, 0x49, 0x44, 0x76, 0x37, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x22, 0x3a, 0x0a, 0x12, 0x4e, 0x65, 0x78, 0x74, 0x55, 0x55, 0x49, 0x44, 0x76, 0x37, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6d,
+	0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x22, 0x2e, 0x0a,
+	0x16, 0x4e, 0x65, 0x78, 0x74, 0x55, 0x55, 0x49, 0x44, 0x76, 0x37, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x41, 0x0a,
+	0x17, 0x4e, 0x65, 0x78, 0x74, 0x55, 0x55, 0x49, 0x44, 0x76, 0x37, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x75, 0x75, 0x69, 0x64,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x75, 0x75, 0x69, 0x64, 0x73, 0x12, 0x10,
+	0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x73, 0x67,
+	0x22, 0x11, 0x0a, 0x0f, 0x4e, 0x65, 0x78, 0x74, 0x55, 0x4c, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x22, 0x38, 0x0a, 0x10, 0x4e, 0x65, 0x78, 0x74, 0x55, 0x4c, 0x49, 0x44, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x6c, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x6c, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6d,
+	0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x22, 0x2c, 0x0a,
+	0x14, 0x4e, 0x65, 0x78, 0x74, 0x55, 0x4c, 0x49, 0x44, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x3f, 0x0a, 0x15, 0x4e,
+	0x65, 0x78, 0x74, 0x55, 0x4c, 0x49, 0x44, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x75, 0x6c, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x05, 0x75, 0x6c, 0x69, 0x64, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x73,
+	0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x22, 0x3c, 0x0a, 0x11,
+	0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x4e, 0x75, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x15, 0x0a, 0x06, 0x74, 0x74, 0x6c, 0x5f, 0x6d, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x05, 0x74, 0x74, 0x6c, 0x4d, 0x73, 0x22, 0x6b, 0x0a, 0x12, 0x52, 0x65,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x4e, 0x75, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x10, 0x0a, 0x03, 0x6e, 0x75, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x6e,
+	0x75, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x78, 0x70, 0x69,
+	0x72, 0x65, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x65, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x41, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x22, 0x4d, 0x0a, 0x11, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x72, 0x6d, 0x4e, 0x75, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6e, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x6e, 0x75, 0x6d,
+	0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x26, 0x0a, 0x12, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72,
+	0x6d, 0x4e, 0x75, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03,
+	0x6d, 0x73, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x22, 0x4d,
+	0x0a, 0x11, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x4e, 0x75, 0x6d, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6e, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x03, 0x6e, 0x75, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x26, 0x0a,
+	0x12, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x4e, 0x75, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6d, 0x73, 0x67, 0x22, 0x0d, 0x0a, 0x0b, 0x50, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0x0e, 0x0a, 0x0c, 0x50, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x32, 0xfd, 0x09, 0x0a, 0x0d, 0x46, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x47, 0x0a, 0x04, 0x4e, 0x65, 0x78, 0x74, 0x12, 0x1e,
+	0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x66, 0x6f, 0x6c, 0x69,
+	0x75, 0x6d, 0x2e, 0x4e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f,
+	0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x66, 0x6f, 0x6c, 0x69,
+	0x75, 0x6d, 0x2e, 0x4e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x56, 0x0a, 0x09, 0x4e, 0x65, 0x78, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x23, 0x2e, 0x66,
+	0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d,
+	0x2e, 0x4e, 0x65, 0x78, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x24, 0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x66,
+	0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x4e, 0x65, 0x78, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x59, 0x0a, 0x0a, 0x4c, 0x65, 0x61, 0x73, 0x65,
+	0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x24, 0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x4c, 0x65, 0x61, 0x73, 0x65, 0x52,
+	0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x66, 0x6f,
+	0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e,
+	0x4c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x62, 0x0a, 0x0d, 0x4e, 0x65, 0x78, 0x74, 0x53, 0x6e, 0x6f, 0x77, 0x66, 0x6c,
+	0x61, 0x6b, 0x65, 0x12, 0x27, 0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x4e, 0x65, 0x78, 0x74, 0x53, 0x6e, 0x6f, 0x77,
+	0x66, 0x6c, 0x61, 0x6b, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x66,
+	0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d,
+	0x2e, 0x4e, 0x65, 0x78, 0x74, 0x53, 0x6e, 0x6f, 0x77, 0x66, 0x6c, 0x61, 0x6b, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x07, 0x4e, 0x65, 0x78, 0x74, 0x53, 0x65,
+	0x71, 0x12, 0x21, 0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x66,
+	0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x4e, 0x65, 0x78, 0x74, 0x53, 0x65, 0x71, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x4e, 0x65, 0x78, 0x74, 0x53, 0x65, 0x71,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x62, 0x0a, 0x0d, 0x4e, 0x65, 0x78, 0x74,
+	0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x74, 0x65, 0x64, 0x12, 0x27, 0x2e, 0x66, 0x6f, 0x6c, 0x69,
+	0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x4e, 0x65,
+	0x78, 0x74, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x74, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x28, 0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x4e, 0x65, 0x78, 0x74, 0x46, 0x6f, 0x72, 0x6d, 0x61,
+	0x74, 0x74, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x59, 0x0a, 0x0a,
+	0x4e, 0x65, 0x78, 0x74, 0x55, 0x55, 0x49, 0x44, 0x76, 0x37, 0x12, 0x24, 0x2e, 0x66, 0x6f, 0x6c,
+	0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x4e,
+	0x65, 0x78, 0x74, 0x55, 0x55, 0x49, 0x44, 0x76, 0x37, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x25, 0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x66, 0x6f,
+	0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x4e, 0x65, 0x78, 0x74, 0x55, 0x55, 0x49, 0x44, 0x76, 0x37, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x68, 0x0a, 0x0f, 0x4e, 0x65, 0x78, 0x74, 0x55,
+	0x55, 0x49, 0x44, 0x76, 0x37, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x29, 0x2e, 0x66, 0x6f, 0x6c,
+	0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x4e,
+	0x65, 0x78, 0x74, 0x55, 0x55, 0x49, 0x44, 0x76, 0x37, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x4e, 0x65, 0x78, 0x74, 0x55, 0x55,
+	0x49, 0x44, 0x76, 0x37, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x53, 0x0a, 0x08, 0x4e, 0x65, 0x78, 0x74, 0x55, 0x4c, 0x49, 0x44, 0x12, 0x22, 0x2e,
+	0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75,
+	0x6d, 0x2e, 0x4e, 0x65, 0x78, 0x74, 0x55, 0x4c, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x23, 0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x66,
+	0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x4e, 0x65, 0x78, 0x74, 0x55, 0x4c, 0x49, 0x44, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x62, 0x0a, 0x0d, 0x4e, 0x65, 0x78, 0x74, 0x55, 0x4c,
+	0x49, 0x44, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x27, 0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x4e, 0x65, 0x78, 0x74,
+	0x55, 0x4c, 0x49, 0x44, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x28, 0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x66, 0x6f,
+	0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x4e, 0x65, 0x78, 0x74, 0x55, 0x4c, 0x49, 0x44, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x59, 0x0a, 0x0a, 0x52, 0x65,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x4e, 0x75, 0x6d, 0x12, 0x24, 0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75,
+	0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x52, 0x65, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x4e, 0x75, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25,
+	0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x66, 0x6f, 0x6c, 0x69,
+	0x75, 0x6d, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x4e, 0x75, 0x6d, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x59, 0x0a, 0x0a, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d,
+	0x4e, 0x75, 0x6d, 0x12, 0x24, 0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x4e,
+	0x75, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x66, 0x6f, 0x6c, 0x69,
+	0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x72, 0x6d, 0x4e, 0x75, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x59, 0x0a, 0x0a, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x4e, 0x75, 0x6d, 0x12, 0x24,
+	0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x66, 0x6f, 0x6c, 0x69,
+	0x75, 0x6d, 0x2e, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x4e, 0x75, 0x6d, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65,
+	0x4e, 0x75, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x04, 0x50,
+	0x69, 0x6e, 0x67, 0x12, 0x1e, 0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x50, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2e, 0x50, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x42, 0x28, 0x5a, 0x26, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x72, 0x79, 0x61, 0x6e, 0x72, 0x65, 0x61, 0x64, 0x62, 0x6f, 0x6f, 0x6b, 0x73,
+	0x2f, 0x66, 0x6f, 0x6c, 0x69, 0x75, 0x6d, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -248,23 +1690,71 @@ func file_api_v1_folium_proto_rawDescGZIP() []byte {
 	return file_api_v1_folium_proto_rawDescData
 }
 
-var file_api_v1_folium_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_api_v1_folium_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
 var file_api_v1_folium_proto_goTypes = []interface{}{
-	(*NextRequest)(nil),  // 0: folium.api.folium.NextRequest
-	(*NextResponse)(nil), // 1: folium.api.folium.NextResponse
-	(*PingRequest)(nil),  // 2: folium.api.folium.PingRequest
-	(*PingResponse)(nil), // 3: folium.api.folium.PingResponse
+	(*NextRequest)(nil),             // 0: folium.api.folium.NextRequest
+	(*NextResponse)(nil),            // 1: folium.api.folium.NextResponse
+	(*NextBatchRequest)(nil),        // 2: folium.api.folium.NextBatchRequest
+	(*NextBatchResponse)(nil),       // 3: folium.api.folium.NextBatchResponse
+	(*LeaseRangeRequest)(nil),       // 4: folium.api.folium.LeaseRangeRequest
+	(*LeaseRangeResponse)(nil),      // 5: folium.api.folium.LeaseRangeResponse
+	(*NextSnowflakeRequest)(nil),    // 6: folium.api.folium.NextSnowflakeRequest
+	(*NextSnowflakeResponse)(nil),   // 7: folium.api.folium.NextSnowflakeResponse
+	(*NextSeqRequest)(nil),          // 8: folium.api.folium.NextSeqRequest
+	(*NextSeqResponse)(nil),         // 9: folium.api.folium.NextSeqResponse
+	(*NextFormattedRequest)(nil),    // 10: folium.api.folium.NextFormattedRequest
+	(*NextFormattedResponse)(nil),   // 11: folium.api.folium.NextFormattedResponse
+	(*NextUUIDv7Request)(nil),       // 12: folium.api.folium.NextUUIDv7Request
+	(*NextUUIDv7Response)(nil),      // 13: folium.api.folium.NextUUIDv7Response
+	(*NextUUIDv7BatchRequest)(nil),  // 14: folium.api.folium.NextUUIDv7BatchRequest
+	(*NextUUIDv7BatchResponse)(nil), // 15: folium.api.folium.NextUUIDv7BatchResponse
+	(*NextULIDRequest)(nil),         // 16: folium.api.folium.NextULIDRequest
+	(*NextULIDResponse)(nil),        // 17: folium.api.folium.NextULIDResponse
+	(*NextULIDBatchRequest)(nil),    // 18: folium.api.folium.NextULIDBatchRequest
+	(*NextULIDBatchResponse)(nil),   // 19: folium.api.folium.NextULIDBatchResponse
+	(*ReserveNumRequest)(nil),       // 20: folium.api.folium.ReserveNumRequest
+	(*ReserveNumResponse)(nil),      // 21: folium.api.folium.ReserveNumResponse
+	(*ConfirmNumRequest)(nil),       // 22: folium.api.folium.ConfirmNumRequest
+	(*ConfirmNumResponse)(nil),      // 23: folium.api.folium.ConfirmNumResponse
+	(*ReleaseNumRequest)(nil),       // 24: folium.api.folium.ReleaseNumRequest
+	(*ReleaseNumResponse)(nil),      // 25: folium.api.folium.ReleaseNumResponse
+	(*PingRequest)(nil),             // 26: folium.api.folium.PingRequest
+	(*PingResponse)(nil),            // 27: folium.api.folium.PingResponse
 }
 var file_api_v1_folium_proto_depIdxs = []int32{
-	0, // 0: folium.api.folium.FoliumService.Next:input_type -> folium.api.folium.NextRequest
-	2, // 1: folium.api.folium.FoliumService.Ping:input_type -> folium.api.folium.PingRequest
-	1, // 2: folium.api.folium.FoliumService.Next:output_type -> folium.api.folium.NextResponse
-	3, // 3: folium.api.folium.FoliumService.Ping:output_type -> folium.api.folium.PingResponse
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0,  // 0: folium.api.folium.FoliumService.Next:input_type -> folium.api.folium.NextRequest
+	2,  // 1: folium.api.folium.FoliumService.NextBatch:input_type -> folium.api.folium.NextBatchRequest
+	4,  // 2: folium.api.folium.FoliumService.LeaseRange:input_type -> folium.api.folium.LeaseRangeRequest
+	6,  // 3: folium.api.folium.FoliumService.NextSnowflake:input_type -> folium.api.folium.NextSnowflakeRequest
+	8,  // 4: folium.api.folium.FoliumService.NextSeq:input_type -> folium.api.folium.NextSeqRequest
+	10, // 5: folium.api.folium.FoliumService.NextFormatted:input_type -> folium.api.folium.NextFormattedRequest
+	12, // 6: folium.api.folium.FoliumService.NextUUIDv7:input_type -> folium.api.folium.NextUUIDv7Request
+	14, // 7: folium.api.folium.FoliumService.NextUUIDv7Batch:input_type -> folium.api.folium.NextUUIDv7BatchRequest
+	16, // 8: folium.api.folium.FoliumService.NextULID:input_type -> folium.api.folium.NextULIDRequest
+	18, // 9: folium.api.folium.FoliumService.NextULIDBatch:input_type -> folium.api.folium.NextULIDBatchRequest
+	20, // 10: folium.api.folium.FoliumService.ReserveNum:input_type -> folium.api.folium.ReserveNumRequest
+	22, // 11: folium.api.folium.FoliumService.ConfirmNum:input_type -> folium.api.folium.ConfirmNumRequest
+	24, // 12: folium.api.folium.FoliumService.ReleaseNum:input_type -> folium.api.folium.ReleaseNumRequest
+	26, // 13: folium.api.folium.FoliumService.Ping:input_type -> folium.api.folium.PingRequest
+	1,  // 14: folium.api.folium.FoliumService.Next:output_type -> folium.api.folium.NextResponse
+	3,  // 15: folium.api.folium.FoliumService.NextBatch:output_type -> folium.api.folium.NextBatchResponse
+	5,  // 16: folium.api.folium.FoliumService.LeaseRange:output_type -> folium.api.folium.LeaseRangeResponse
+	7,  // 17: folium.api.folium.FoliumService.NextSnowflake:output_type -> folium.api.folium.NextSnowflakeResponse
+	9,  // 18: folium.api.folium.FoliumService.NextSeq:output_type -> folium.api.folium.NextSeqResponse
+	11, // 19: folium.api.folium.FoliumService.NextFormatted:output_type -> folium.api.folium.NextFormattedResponse
+	13, // 20: folium.api.folium.FoliumService.NextUUIDv7:output_type -> folium.api.folium.NextUUIDv7Response
+	15, // 21: folium.api.folium.FoliumService.NextUUIDv7Batch:output_type -> folium.api.folium.NextUUIDv7BatchResponse
+	17, // 22: folium.api.folium.FoliumService.NextULID:output_type -> folium.api.folium.NextULIDResponse
+	19, // 23: folium.api.folium.FoliumService.NextULIDBatch:output_type -> folium.api.folium.NextULIDBatchResponse
+	21, // 24: folium.api.folium.FoliumService.ReserveNum:output_type -> folium.api.folium.ReserveNumResponse
+	23, // 25: folium.api.folium.FoliumService.ConfirmNum:output_type -> folium.api.folium.ConfirmNumResponse
+	25, // 26: folium.api.folium.FoliumService.ReleaseNum:output_type -> folium.api.folium.ReleaseNumResponse
+	27, // 27: folium.api.folium.FoliumService.Ping:output_type -> folium.api.folium.PingResponse
+	14, // [14:28] is the sub-list for method output_type
+	0,  // [0:14] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
 }
 
 func init() { file_api_v1_folium_proto_init() }
@@ -298,7 +1788,7 @@ func file_api_v1_folium_proto_init() {
 			}
 		}
 		file_api_v1_folium_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PingRequest); i {
+			switch v := v.(*NextBatchRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -310,6 +1800,294 @@ func file_api_v1_folium_proto_init() {
 			}
 		}
 		file_api_v1_folium_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NextBatchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_folium_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LeaseRangeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_folium_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LeaseRangeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_folium_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NextSnowflakeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_folium_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NextSnowflakeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_folium_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NextSeqRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_folium_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NextSeqResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_folium_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NextFormattedRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_folium_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NextFormattedResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_folium_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NextUUIDv7Request); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_folium_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NextUUIDv7Response); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_folium_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NextUUIDv7BatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_folium_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NextUUIDv7BatchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_folium_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NextULIDRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_folium_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NextULIDResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_folium_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NextULIDBatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_folium_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NextULIDBatchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_folium_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReserveNumRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_folium_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReserveNumResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_folium_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfirmNumRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_folium_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfirmNumResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_folium_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReleaseNumRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_folium_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReleaseNumResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_folium_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_folium_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PingResponse); i {
 			case 0:
 				return &v.state
@@ -328,7 +2106,7 @@ func file_api_v1_folium_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_api_v1_folium_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   28,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
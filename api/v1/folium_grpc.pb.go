@@ -23,6 +23,18 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type FoliumServiceClient interface {
 	Next(ctx context.Context, in *NextRequest, opts ...grpc.CallOption) (*NextResponse, error)
+	NextBatch(ctx context.Context, in *NextBatchRequest, opts ...grpc.CallOption) (*NextBatchResponse, error)
+	LeaseRange(ctx context.Context, in *LeaseRangeRequest, opts ...grpc.CallOption) (*LeaseRangeResponse, error)
+	NextSnowflake(ctx context.Context, in *NextSnowflakeRequest, opts ...grpc.CallOption) (*NextSnowflakeResponse, error)
+	NextSeq(ctx context.Context, in *NextSeqRequest, opts ...grpc.CallOption) (*NextSeqResponse, error)
+	NextFormatted(ctx context.Context, in *NextFormattedRequest, opts ...grpc.CallOption) (*NextFormattedResponse, error)
+	NextUUIDv7(ctx context.Context, in *NextUUIDv7Request, opts ...grpc.CallOption) (*NextUUIDv7Response, error)
+	NextUUIDv7Batch(ctx context.Context, in *NextUUIDv7BatchRequest, opts ...grpc.CallOption) (*NextUUIDv7BatchResponse, error)
+	NextULID(ctx context.Context, in *NextULIDRequest, opts ...grpc.CallOption) (*NextULIDResponse, error)
+	NextULIDBatch(ctx context.Context, in *NextULIDBatchRequest, opts ...grpc.CallOption) (*NextULIDBatchResponse, error)
+	ReserveNum(ctx context.Context, in *ReserveNumRequest, opts ...grpc.CallOption) (*ReserveNumResponse, error)
+	ConfirmNum(ctx context.Context, in *ConfirmNumRequest, opts ...grpc.CallOption) (*ConfirmNumResponse, error)
+	ReleaseNum(ctx context.Context, in *ReleaseNumRequest, opts ...grpc.CallOption) (*ReleaseNumResponse, error)
 	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
 }
 
@@ -43,6 +55,114 @@ func (c *foliumServiceClient) Next(ctx context.Context, in *NextRequest, opts ..
 	return out, nil
 }
 
+func (c *foliumServiceClient) NextBatch(ctx context.Context, in *NextBatchRequest, opts ...grpc.CallOption) (*NextBatchResponse, error) {
+	out := new(NextBatchResponse)
+	err := c.cc.Invoke(ctx, "/folium.api.folium.FoliumService/NextBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *foliumServiceClient) LeaseRange(ctx context.Context, in *LeaseRangeRequest, opts ...grpc.CallOption) (*LeaseRangeResponse, error) {
+	out := new(LeaseRangeResponse)
+	err := c.cc.Invoke(ctx, "/folium.api.folium.FoliumService/LeaseRange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *foliumServiceClient) NextSnowflake(ctx context.Context, in *NextSnowflakeRequest, opts ...grpc.CallOption) (*NextSnowflakeResponse, error) {
+	out := new(NextSnowflakeResponse)
+	err := c.cc.Invoke(ctx, "/folium.api.folium.FoliumService/NextSnowflake", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *foliumServiceClient) NextSeq(ctx context.Context, in *NextSeqRequest, opts ...grpc.CallOption) (*NextSeqResponse, error) {
+	out := new(NextSeqResponse)
+	err := c.cc.Invoke(ctx, "/folium.api.folium.FoliumService/NextSeq", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *foliumServiceClient) NextFormatted(ctx context.Context, in *NextFormattedRequest, opts ...grpc.CallOption) (*NextFormattedResponse, error) {
+	out := new(NextFormattedResponse)
+	err := c.cc.Invoke(ctx, "/folium.api.folium.FoliumService/NextFormatted", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *foliumServiceClient) NextUUIDv7(ctx context.Context, in *NextUUIDv7Request, opts ...grpc.CallOption) (*NextUUIDv7Response, error) {
+	out := new(NextUUIDv7Response)
+	err := c.cc.Invoke(ctx, "/folium.api.folium.FoliumService/NextUUIDv7", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *foliumServiceClient) NextUUIDv7Batch(ctx context.Context, in *NextUUIDv7BatchRequest, opts ...grpc.CallOption) (*NextUUIDv7BatchResponse, error) {
+	out := new(NextUUIDv7BatchResponse)
+	err := c.cc.Invoke(ctx, "/folium.api.folium.FoliumService/NextUUIDv7Batch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *foliumServiceClient) NextULID(ctx context.Context, in *NextULIDRequest, opts ...grpc.CallOption) (*NextULIDResponse, error) {
+	out := new(NextULIDResponse)
+	err := c.cc.Invoke(ctx, "/folium.api.folium.FoliumService/NextULID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *foliumServiceClient) NextULIDBatch(ctx context.Context, in *NextULIDBatchRequest, opts ...grpc.CallOption) (*NextULIDBatchResponse, error) {
+	out := new(NextULIDBatchResponse)
+	err := c.cc.Invoke(ctx, "/folium.api.folium.FoliumService/NextULIDBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *foliumServiceClient) ReserveNum(ctx context.Context, in *ReserveNumRequest, opts ...grpc.CallOption) (*ReserveNumResponse, error) {
+	out := new(ReserveNumResponse)
+	err := c.cc.Invoke(ctx, "/folium.api.folium.FoliumService/ReserveNum", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *foliumServiceClient) ConfirmNum(ctx context.Context, in *ConfirmNumRequest, opts ...grpc.CallOption) (*ConfirmNumResponse, error) {
+	out := new(ConfirmNumResponse)
+	err := c.cc.Invoke(ctx, "/folium.api.folium.FoliumService/ConfirmNum", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *foliumServiceClient) ReleaseNum(ctx context.Context, in *ReleaseNumRequest, opts ...grpc.CallOption) (*ReleaseNumResponse, error) {
+	out := new(ReleaseNumResponse)
+	err := c.cc.Invoke(ctx, "/folium.api.folium.FoliumService/ReleaseNum", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *foliumServiceClient) Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error) {
 	out := new(PingResponse)
 	err := c.cc.Invoke(ctx, "/folium.api.folium.FoliumService/Ping", in, out, opts...)
@@ -57,6 +177,18 @@ func (c *foliumServiceClient) Ping(ctx context.Context, in *PingRequest, opts ..
 // for forward compatibility
 type FoliumServiceServer interface {
 	Next(context.Context, *NextRequest) (*NextResponse, error)
+	NextBatch(context.Context, *NextBatchRequest) (*NextBatchResponse, error)
+	LeaseRange(context.Context, *LeaseRangeRequest) (*LeaseRangeResponse, error)
+	NextSnowflake(context.Context, *NextSnowflakeRequest) (*NextSnowflakeResponse, error)
+	NextSeq(context.Context, *NextSeqRequest) (*NextSeqResponse, error)
+	NextFormatted(context.Context, *NextFormattedRequest) (*NextFormattedResponse, error)
+	NextUUIDv7(context.Context, *NextUUIDv7Request) (*NextUUIDv7Response, error)
+	NextUUIDv7Batch(context.Context, *NextUUIDv7BatchRequest) (*NextUUIDv7BatchResponse, error)
+	NextULID(context.Context, *NextULIDRequest) (*NextULIDResponse, error)
+	NextULIDBatch(context.Context, *NextULIDBatchRequest) (*NextULIDBatchResponse, error)
+	ReserveNum(context.Context, *ReserveNumRequest) (*ReserveNumResponse, error)
+	ConfirmNum(context.Context, *ConfirmNumRequest) (*ConfirmNumResponse, error)
+	ReleaseNum(context.Context, *ReleaseNumRequest) (*ReleaseNumResponse, error)
 	Ping(context.Context, *PingRequest) (*PingResponse, error)
 	mustEmbedUnimplementedFoliumServiceServer()
 }
@@ -68,6 +200,42 @@ type UnimplementedFoliumServiceServer struct {
 func (UnimplementedFoliumServiceServer) Next(context.Context, *NextRequest) (*NextResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Next not implemented")
 }
+func (UnimplementedFoliumServiceServer) NextBatch(context.Context, *NextBatchRequest) (*NextBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NextBatch not implemented")
+}
+func (UnimplementedFoliumServiceServer) LeaseRange(context.Context, *LeaseRangeRequest) (*LeaseRangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaseRange not implemented")
+}
+func (UnimplementedFoliumServiceServer) NextSnowflake(context.Context, *NextSnowflakeRequest) (*NextSnowflakeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NextSnowflake not implemented")
+}
+func (UnimplementedFoliumServiceServer) NextSeq(context.Context, *NextSeqRequest) (*NextSeqResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NextSeq not implemented")
+}
+func (UnimplementedFoliumServiceServer) NextFormatted(context.Context, *NextFormattedRequest) (*NextFormattedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NextFormatted not implemented")
+}
+func (UnimplementedFoliumServiceServer) NextUUIDv7(context.Context, *NextUUIDv7Request) (*NextUUIDv7Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NextUUIDv7 not implemented")
+}
+func (UnimplementedFoliumServiceServer) NextUUIDv7Batch(context.Context, *NextUUIDv7BatchRequest) (*NextUUIDv7BatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NextUUIDv7Batch not implemented")
+}
+func (UnimplementedFoliumServiceServer) NextULID(context.Context, *NextULIDRequest) (*NextULIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NextULID not implemented")
+}
+func (UnimplementedFoliumServiceServer) NextULIDBatch(context.Context, *NextULIDBatchRequest) (*NextULIDBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NextULIDBatch not implemented")
+}
+func (UnimplementedFoliumServiceServer) ReserveNum(context.Context, *ReserveNumRequest) (*ReserveNumResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReserveNum not implemented")
+}
+func (UnimplementedFoliumServiceServer) ConfirmNum(context.Context, *ConfirmNumRequest) (*ConfirmNumResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmNum not implemented")
+}
+func (UnimplementedFoliumServiceServer) ReleaseNum(context.Context, *ReleaseNumRequest) (*ReleaseNumResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseNum not implemented")
+}
 func (UnimplementedFoliumServiceServer) Ping(context.Context, *PingRequest) (*PingResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
 }
@@ -102,6 +270,222 @@ func _FoliumService_Next_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FoliumService_NextBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NextBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FoliumServiceServer).NextBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/folium.api.folium.FoliumService/NextBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FoliumServiceServer).NextBatch(ctx, req.(*NextBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FoliumService_LeaseRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaseRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FoliumServiceServer).LeaseRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/folium.api.folium.FoliumService/LeaseRange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FoliumServiceServer).LeaseRange(ctx, req.(*LeaseRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FoliumService_NextSnowflake_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NextSnowflakeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FoliumServiceServer).NextSnowflake(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/folium.api.folium.FoliumService/NextSnowflake",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FoliumServiceServer).NextSnowflake(ctx, req.(*NextSnowflakeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FoliumService_NextSeq_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NextSeqRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FoliumServiceServer).NextSeq(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/folium.api.folium.FoliumService/NextSeq",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FoliumServiceServer).NextSeq(ctx, req.(*NextSeqRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FoliumService_NextFormatted_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NextFormattedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FoliumServiceServer).NextFormatted(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/folium.api.folium.FoliumService/NextFormatted",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FoliumServiceServer).NextFormatted(ctx, req.(*NextFormattedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FoliumService_NextUUIDv7_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NextUUIDv7Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FoliumServiceServer).NextUUIDv7(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/folium.api.folium.FoliumService/NextUUIDv7",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FoliumServiceServer).NextUUIDv7(ctx, req.(*NextUUIDv7Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FoliumService_NextUUIDv7Batch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NextUUIDv7BatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FoliumServiceServer).NextUUIDv7Batch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/folium.api.folium.FoliumService/NextUUIDv7Batch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FoliumServiceServer).NextUUIDv7Batch(ctx, req.(*NextUUIDv7BatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FoliumService_NextULID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NextULIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FoliumServiceServer).NextULID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/folium.api.folium.FoliumService/NextULID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FoliumServiceServer).NextULID(ctx, req.(*NextULIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FoliumService_NextULIDBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NextULIDBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FoliumServiceServer).NextULIDBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/folium.api.folium.FoliumService/NextULIDBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FoliumServiceServer).NextULIDBatch(ctx, req.(*NextULIDBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FoliumService_ReserveNum_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveNumRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FoliumServiceServer).ReserveNum(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/folium.api.folium.FoliumService/ReserveNum",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FoliumServiceServer).ReserveNum(ctx, req.(*ReserveNumRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FoliumService_ConfirmNum_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmNumRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FoliumServiceServer).ConfirmNum(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/folium.api.folium.FoliumService/ConfirmNum",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FoliumServiceServer).ConfirmNum(ctx, req.(*ConfirmNumRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FoliumService_ReleaseNum_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseNumRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FoliumServiceServer).ReleaseNum(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/folium.api.folium.FoliumService/ReleaseNum",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FoliumServiceServer).ReleaseNum(ctx, req.(*ReleaseNumRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _FoliumService_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PingRequest)
 	if err := dec(in); err != nil {
@@ -131,6 +515,54 @@ var FoliumService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Next",
 			Handler:    _FoliumService_Next_Handler,
 		},
+		{
+			MethodName: "NextBatch",
+			Handler:    _FoliumService_NextBatch_Handler,
+		},
+		{
+			MethodName: "LeaseRange",
+			Handler:    _FoliumService_LeaseRange_Handler,
+		},
+		{
+			MethodName: "NextSnowflake",
+			Handler:    _FoliumService_NextSnowflake_Handler,
+		},
+		{
+			MethodName: "NextSeq",
+			Handler:    _FoliumService_NextSeq_Handler,
+		},
+		{
+			MethodName: "NextFormatted",
+			Handler:    _FoliumService_NextFormatted_Handler,
+		},
+		{
+			MethodName: "NextUUIDv7",
+			Handler:    _FoliumService_NextUUIDv7_Handler,
+		},
+		{
+			MethodName: "NextUUIDv7Batch",
+			Handler:    _FoliumService_NextUUIDv7Batch_Handler,
+		},
+		{
+			MethodName: "NextULID",
+			Handler:    _FoliumService_NextULID_Handler,
+		},
+		{
+			MethodName: "NextULIDBatch",
+			Handler:    _FoliumService_NextULIDBatch_Handler,
+		},
+		{
+			MethodName: "ReserveNum",
+			Handler:    _FoliumService_ReserveNum_Handler,
+		},
+		{
+			MethodName: "ConfirmNum",
+			Handler:    _FoliumService_ConfirmNum_Handler,
+		},
+		{
+			MethodName: "ReleaseNum",
+			Handler:    _FoliumService_ReleaseNum_Handler,
+		},
 		{
 			MethodName: "Ping",
 			Handler:    _FoliumService_Ping_Handler,
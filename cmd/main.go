@@ -1,33 +1,184 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"fmt"
 	"log"
+	"math"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
+	_ "time/tzdata" // time zones of date scoped sequences, the runtime image may not ship zoneinfo
 
+	"github.com/ryanreadbooks/folium/internal/segment/dao"
+	"github.com/ryanreadbooks/folium/internal/segment/idgen"
 	segsrv "github.com/ryanreadbooks/folium/internal/segment/server"
+	"github.com/ryanreadbooks/folium/internal/snowflake"
 )
 
 var (
-	httpPort int
-	grpcPort int
+	httpPort   int
+	grpcPort   int
+	adminToken string
+	storeName  string
+	watermark  float64
+	minStep    uint
+	maxStep    uint
+	stepWindow time.Duration
+	idleTTL    time.Duration
+	maxBuffers int
+
+	autoCreate bool
+
+	warmUp            bool
+	warmUpKeys        string
+	warmUpConcurrency int
+	warmUpTimeout     time.Duration
+
+	store dao.AllocStore
+
+	sfEnabled    bool
+	workerId     int64
+	leaseTTL     time.Duration
+	spareWorker  bool
+	backwardWait time.Duration
+	clockSkew    time.Duration
+	sfTimeBits   uint
+	sfWorkerBits uint
+	sfSeqBits    uint
+	sfEpoch      int64
 )
 
 func init() {
 	flag.IntVar(&httpPort, "httpPort", 9527, "the http server port")
 	flag.IntVar(&grpcPort, "grpcPort", 9528, "the grpc server port")
+	flag.StringVar(&adminToken, "adminToken", os.Getenv("FOLIUM_ADMIN_TOKEN"), "the bearer token of admin apis, admin apis are not served if empty, defaults to env FOLIUM_ADMIN_TOKEN")
+	flag.StringVar(&storeName, "store", dao.DefaultStore, fmt.Sprintf("the store backend, one of %v", dao.Stores()))
+	flag.Float64Var(&watermark, "watermark", 0.85, "the consumed ratio of a segment at which the standby segment is preloaded")
+	flag.UintVar(&minStep, "minStep", 100, "the min adaptive step of keys without their own bounds")
+	flag.UintVar(&maxStep, "maxStep", 100000, "the max adaptive step of keys without their own bounds")
+	flag.DurationVar(&stepWindow, "stepWindow", time.Minute*15, "step grows if a segment is used up within this window and shrinks if it lasts twice as long, negative disables adaptive step")
+	flag.DurationVar(&idleTTL, "idleTTL", time.Minute*30, "the in-memory buffer of a key is evicted if the key is not requested for this long, negative disables it")
+	flag.IntVar(&maxBuffers, "maxBuffers", 10000, "the max number of in-memory key buffers, negative means no limit")
+	flag.BoolVar(&autoCreate, "autoCreate", false, "create keys which do not exist on their first request instead of returning not found")
+	flag.BoolVar(&warmUp, "warmUp", false, "load buffers of keys before reporting ready")
+	flag.StringVar(&warmUpKeys, "warmUpKeys", "", "comma separated keys to warm up, all the keys in store are warmed up if empty")
+	flag.IntVar(&warmUpConcurrency, "warmUpConcurrency", 8, "the max number of keys to warm up at a time")
+	flag.DurationVar(&warmUpTimeout, "warmUpTimeout", time.Second*30, "the max duration of warming up")
+
+	defaultLayout := snowflake.DefaultLayout()
+	flag.BoolVar(&sfEnabled, "snowflake", false, "serve snowflake ids, a worker id is leased from the worker_lease table unless workerId is given")
+	flag.Int64Var(&workerId, "workerId", -1, "the snowflake worker id of this node, negative means leasing one from db")
+	flag.DurationVar(&leaseTTL, "leaseTTL", time.Second*30, "the ttl of the leased snowflake worker id")
+	flag.BoolVar(&spareWorker, "spareWorker", false, "lease a spare snowflake worker id to switch to when clock moves backwards")
+	flag.DurationVar(&backwardWait, "maxBackwardWait", time.Millisecond*5, "the max clock rollback to wait out for snowflake")
+	flag.DurationVar(&clockSkew, "maxClockSkew", time.Second, "the max allowed clock skew from db for snowflake")
+	flag.UintVar(&sfTimeBits, "sfTimeBits", uint(defaultLayout.TimeBits), "the snowflake timestamp bits")
+	flag.UintVar(&sfWorkerBits, "sfWorkerBits", uint(defaultLayout.WorkerBits), "the snowflake worker id bits")
+	flag.UintVar(&sfSeqBits, "sfSeqBits", uint(defaultLayout.SeqBits), "the snowflake sequence bits")
+	flag.Int64Var(&sfEpoch, "sfEpoch", defaultLayout.Epoch, "the snowflake epoch in unix ms")
+}
+
+// checkFlags rejects flag values which do not fit in the types they are converted to
+func checkFlags() error {
+	if minStep > math.MaxUint32 || maxStep > math.MaxUint32 {
+		return fmt.Errorf("minStep %d and maxStep %d should be at most %d", minStep, maxStep, uint32(math.MaxUint32))
+	}
+
+	if sfEnabled {
+		const maxBits = 63
+		if sfTimeBits > maxBits || sfWorkerBits > maxBits || sfSeqBits > maxBits ||
+			sfTimeBits+sfWorkerBits+sfSeqBits > maxBits {
+			return fmt.Errorf("sfTimeBits %d, sfWorkerBits %d and sfSeqBits %d should add up to at most %d bits",
+				sfTimeBits, sfWorkerBits, sfSeqBits, maxBits)
+		}
+	}
+
+	return nil
+}
+
+func InitStore() {
+	var err error
+	store, err = dao.OpenStore(storeName)
+	if err != nil {
+		log.Fatalf("failed to open store: %v", err)
+	}
+}
+
+func InitSnowflake() {
+	layout := snowflake.Layout{
+		TimeBits:   uint8(sfTimeBits),
+		WorkerBits: uint8(sfWorkerBits),
+		SeqBits:    uint8(sfSeqBits),
+		Epoch:      sfEpoch,
+	}
+	leaseStore, _ := store.(dao.LeaseStore)
+	err := snowflake.Init(snowflake.Config{
+		Store:           leaseStore,
+		Layout:          layout,
+		WorkerId:        workerId,
+		LeaseTTL:        leaseTTL,
+		SpareWorker:     spareWorker,
+		MaxBackwardWait: backwardWait,
+		MaxClockSkew:    clockSkew,
+	})
+	if err != nil {
+		log.Fatalf("failed to init snowflake: %v", err)
+	}
 }
 
 func ServeSegment() {
-	segsrv.InitHttp(httpPort)
-	segsrv.InitGrpc(grpcPort)
+	err := idgen.Init(idgen.Config{
+		Store:      store,
+		Watermark:  watermark,
+		MinStep:    uint32(minStep),
+		MaxStep:    uint32(maxStep),
+		StepWindow: stepWindow,
+		IdleTTL:    idleTTL,
+		MaxBuffers: maxBuffers,
+		WarmUp:     warmUp,
+		AutoCreate: autoCreate,
+	})
+	if err != nil {
+		log.Fatalf("failed to init segment idgen: %v", err)
+	}
+	segsrv.InitHttp(httpPort, adminToken)
+	segsrv.InitGrpc(grpcPort, adminToken)
+
+	if warmUp {
+		go WarmUpSegment()
+	}
+}
+
+// servers report not ready until warming up is done
+func WarmUpSegment() {
+	var keys []string
+	for _, key := range strings.Split(warmUpKeys, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), warmUpTimeout)
+	defer cancel()
+	if err := idgen.WarmUp(ctx, keys, warmUpConcurrency); err != nil {
+		log.Printf("segment warm up err: %v\n", err)
+	}
 }
 
 func main() {
 	flag.Parse()
+	if err := checkFlags(); err != nil {
+		log.Fatalf("invalid flags: %v", err)
+	}
 
+	InitStore()
+	if sfEnabled {
+		InitSnowflake()
+	}
 	ServeSegment()
 
 	// gracefully shutdown
@@ -37,4 +188,5 @@ func main() {
 	log.Printf("folium got a signal: %v\n", sig.String())
 
 	segsrv.CloseServer()
+	store.Close()
 }
@@ -5,7 +5,9 @@ go 1.21
 require (
 	github.com/gin-gonic/gin v1.10.0
 	github.com/go-sql-driver/mysql v1.8.1
+	github.com/lib/pq v1.10.9
 	github.com/stretchr/testify v1.9.0
+	go.etcd.io/bbolt v1.3.10
 	google.golang.org/grpc v1.64.0
 	google.golang.org/protobuf v1.34.1
 )
@@ -3,18 +3,91 @@ package dao
 // segment table
 const (
 	TableName    = "alloc_table"
-	allocColumns = "id, biz_key, cur_id, step, created_at, updated_at"
+	allocColumns = "id, biz_key, cur_id, step, min_step, max_step, min_id, max_id, exhaust_policy, reset_period, time_zone, id_format, obfuscate_seed, gapless, disabled, created_at, updated_at"
 
 	defaultStep  uint32 = 1000
 	defaultCurId uint64 = 1
 )
 
+// ExhaustPolicy decides what happens when ids of a key reach max_id
+type ExhaustPolicy uint8
+
+const (
+	ExhaustError ExhaustPolicy = iota // ErrKeyExhausted is returned
+	ExhaustCycle                      // ids start over from min_id
+)
+
+// ResetPeriod is how often the sequence of a key starts over,
+// the sequence of each period is counted in its own alloc row
+type ResetPeriod uint8
+
+const (
+	ResetNone    ResetPeriod = iota // key is a plain sequence
+	ResetDaily                      // sequence starts over every day
+	ResetMonthly                    // sequence starts over every month
+)
+
 // dao Alloc instance representation
 type Alloc struct {
-	Id        int64  // id primary key
-	Key       string // biz_key unique key
-	CurId     uint64 // cur_id
-	Step      uint32 // step
-	CreatedAt int64  // created_at
-	UpdatedAt int64  // updated_at
+	Id        int64         // id primary key
+	Key       string        // biz_key unique key
+	CurId     uint64        // cur_id
+	Step      uint32        // step
+	MinStep   uint32        // min_step, lower bound of adaptive step, zero means not set
+	MaxStep   uint32        // max_step, upper bound of adaptive step, zero means not set
+	MinId     uint64        // min_id, ids start from and cycle back to min_id, zero means 1
+	MaxId     uint64        // max_id, the largest id which can be taken, zero means no limit
+	Exhaust   ExhaustPolicy // exhaust_policy, what to do when max_id is reached
+	Period    ResetPeriod   // reset_period, how often the sequence of key starts over
+	TimeZone  string        // time_zone, IANA name of the zone where periods roll over, empty means UTC
+	Format    string        // id_format, template which ids of key are rendered with, empty means not set
+	Seed      uint64        // obfuscate_seed, secret which ids of key are obfuscated with, zero means not obfuscated
+	Gapless   bool          // gapless, ids of gapless key are reserved one by one and never skipped
+	Disabled  bool          // disabled, no id can be taken from a disabled key
+	CreatedAt int64         // created_at
+	UpdatedAt int64         // updated_at
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scan a row of allocColumns into alloc
+func scanAlloc(row rowScanner, alloc *Alloc) error {
+	return row.Scan(&alloc.Id,
+		&alloc.Key,
+		&alloc.CurId,
+		&alloc.Step,
+		&alloc.MinStep,
+		&alloc.MaxStep,
+		&alloc.MinId,
+		&alloc.MaxId,
+		&alloc.Exhaust,
+		&alloc.Period,
+		&alloc.TimeZone,
+		&alloc.Format,
+		&alloc.Seed,
+		&alloc.Gapless,
+		&alloc.Disabled,
+		&alloc.CreatedAt,
+		&alloc.UpdatedAt)
+}
+
+// takeRange returns the range [begin, end) of at most step ids taken from curId within [minId, maxId],
+// ErrKeyExhausted is returned if nothing is left and the key does not cycle
+func takeRange(curId uint64, step uint32, minId, maxId uint64, policy ExhaustPolicy) (uint64, uint64, error) {
+	lo := max(minId, defaultCurId)
+	begin := max(curId, lo)
+	if maxId != 0 && begin > maxId {
+		if policy != ExhaustCycle {
+			return 0, 0, ErrKeyExhausted
+		}
+		begin = lo
+	}
+
+	end := begin + uint64(step)
+	if maxId != 0 && end > maxId+1 {
+		end = maxId + 1
+	}
+	return begin, end, nil
 }
@@ -0,0 +1,612 @@
+package dao
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/ryanreadbooks/folium/internal/pkg"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	StoreBolt = "bolt"
+
+	ENV_BOLT_PATH = "ENV_BOLT_PATH"
+
+	defaultBoltPath = "folium.db"
+)
+
+var (
+	allocBucket   = []byte(TableName)
+	leaseBucket   = []byte(LeaseTableName)
+	gaplessBucket = []byte(GaplessTableName)
+)
+
+func init() {
+	RegisterStore(StoreBolt, func() (AllocStore, error) {
+		return NewBoltStore(os.Getenv(ENV_BOLT_PATH))
+	})
+}
+
+// BoltStore is the AllocStore and LeaseStore backed by a local bbolt file,
+// it is meant for standalone deployments where only one folium node is running.
+//
+// Every write is committed and synced to disk before it returns, so a segment
+// is handed out only after cur_id has been advanced in the file.
+type BoltStore struct {
+	db *bolt.DB
+}
+
+var (
+	_ AllocStore   = (*BoltStore)(nil)
+	_ LeaseStore   = (*BoltStore)(nil)
+	_ GaplessStore = (*BoltStore)(nil)
+)
+
+// open bolt store at path, the file is created if it does not exist
+func NewBoltStore(path string) (*BoltStore, error) {
+	if path == "" {
+		path = defaultBoltPath
+	}
+
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second * 10})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bolt file %s: %v", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{allocBucket, leaseBucket, gaplessBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to init bolt buckets: %v", err)
+	}
+
+	log.Printf("bolt store inited at %s\n", path)
+	return &BoltStore{db: db}, nil
+}
+
+func (s *BoltStore) Close() error {
+	err := s.db.Close()
+	if err != nil {
+		log.Printf("can not close bolt: %v", err)
+		return err
+	}
+	log.Println("bolt closed")
+	return nil
+}
+
+func getBoltAlloc(b *bolt.Bucket, key string) (*Alloc, error) {
+	data := b.Get([]byte(key))
+	if data == nil {
+		return nil, nil
+	}
+
+	var alloc Alloc
+	if err := json.Unmarshal(data, &alloc); err != nil {
+		return nil, err
+	}
+	return &alloc, nil
+}
+
+func putBoltAlloc(b *bolt.Bucket, alloc *Alloc) error {
+	if alloc.Id == 0 {
+		id, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+		alloc.Id = int64(id)
+	}
+
+	data, err := json.Marshal(alloc)
+	if err != nil {
+		return err
+	}
+	return b.Put([]byte(alloc.Key), data)
+}
+
+func (s *BoltStore) QueryByKey(ctx context.Context, key string) (*Alloc, error) {
+	var alloc *Alloc
+	err := s.db.View(func(tx *bolt.Tx) error {
+		var err error
+		alloc, err = getBoltAlloc(tx.Bucket(allocBucket), key)
+		return err
+	})
+	if err != nil {
+		log.Printf("dao bolt query key err: %v\n", err)
+		return nil, pkg.ErrDb.Message(err.Error())
+	}
+
+	if alloc == nil {
+		return nil, ErrKeyNotFound
+	}
+
+	return alloc, nil
+}
+
+func (s *BoltStore) QueryAll(ctx context.Context) ([]*Alloc, error) {
+	var allocs []*Alloc
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(allocBucket).ForEach(func(k, v []byte) error {
+			var alloc Alloc
+			if err := json.Unmarshal(v, &alloc); err != nil {
+				return err
+			}
+			allocs = append(allocs, &alloc)
+			return nil
+		})
+	})
+	if err != nil {
+		log.Printf("dao bolt query all err: %v\n", err)
+		return nil, pkg.ErrDb.Message(err.Error())
+	}
+
+	return allocs, nil
+}
+
+func (s *BoltStore) QueryAllKeys(ctx context.Context) ([]string, error) {
+	var keys []string
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(allocBucket).ForEach(func(k, v []byte) error {
+			keys = append(keys, string(k))
+			return nil
+		})
+	})
+	if err != nil {
+		log.Printf("dao bolt query all keys err: %v\n", err)
+		return nil, pkg.ErrDb.Message(err.Error())
+	}
+
+	return keys, nil
+}
+
+// keys of bolt bucket are iterated in byte order, so the page is read by seeking the cursor
+func (s *BoltStore) QueryPage(ctx context.Context, afterKey string, limit int) ([]*Alloc, error) {
+	var allocs []*Alloc
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(allocBucket).Cursor()
+		k, v := c.Seek([]byte(afterKey))
+		if k != nil && string(k) == afterKey {
+			k, v = c.Next()
+		}
+		for ; k != nil && len(allocs) < limit; k, v = c.Next() {
+			var alloc Alloc
+			if err := json.Unmarshal(v, &alloc); err != nil {
+				return err
+			}
+			allocs = append(allocs, &alloc)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("dao bolt query page err: %v\n", err)
+		return nil, pkg.ErrDb.Message(err.Error())
+	}
+
+	return allocs, nil
+}
+
+func (s *BoltStore) CreateKey(ctx context.Context, alloc *Alloc) error {
+	if alloc == nil {
+		return ErrNilAlloc
+	}
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(allocBucket)
+		if b.Get([]byte(alloc.Key)) != nil {
+			return ErrKeyExists
+		}
+
+		return putBoltAlloc(b, createAlloc(alloc, time.Now().UnixMilli()))
+	})
+	if err != nil {
+		if pkgErr, ok := err.(*pkg.Err); ok {
+			return pkgErr
+		}
+		log.Printf("dao bolt create key err: %v\n", err)
+		return pkg.ErrDb.Message(err.Error())
+	}
+
+	return nil
+}
+
+// updateBoltAlloc applies fn to the alloc of key and puts it back, the updated alloc is returned
+func (s *BoltStore) updateBoltAlloc(key string, fn func(alloc *Alloc) error) (*Alloc, error) {
+	var alloc *Alloc
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(allocBucket)
+		var err error
+		alloc, err = getBoltAlloc(b, key)
+		if err != nil {
+			return err
+		}
+		if alloc == nil {
+			return ErrKeyNotFound
+		}
+
+		if err = fn(alloc); err != nil {
+			return err
+		}
+		return putBoltAlloc(b, alloc)
+	})
+	if err != nil {
+		if pkgErr, ok := err.(*pkg.Err); ok {
+			return nil, pkgErr
+		}
+		log.Printf("dao bolt update key err: %v\n", err)
+		return nil, pkg.ErrDb.Message(err.Error())
+	}
+
+	return alloc, nil
+}
+
+func (s *BoltStore) UpdateStep(ctx context.Context, key string, step, minStep, maxStep uint32) error {
+	_, err := s.updateBoltAlloc(key, func(alloc *Alloc) error {
+		alloc.Step, alloc.MinStep, alloc.MaxStep = step, minStep, maxStep
+		alloc.UpdatedAt = time.Now().UnixMilli()
+		return nil
+	})
+	return err
+}
+
+func (s *BoltStore) UpdateFormat(ctx context.Context, key string, format string) error {
+	_, err := s.updateBoltAlloc(key, func(alloc *Alloc) error {
+		alloc.Format = format
+		alloc.UpdatedAt = time.Now().UnixMilli()
+		return nil
+	})
+	return err
+}
+
+func (s *BoltStore) SetDisabled(ctx context.Context, key string, disabled bool) error {
+	_, err := s.updateBoltAlloc(key, func(alloc *Alloc) error {
+		alloc.Disabled = disabled
+		alloc.UpdatedAt = time.Now().UnixMilli()
+		return nil
+	})
+	return err
+}
+
+func (s *BoltStore) AdvanceCurId(ctx context.Context, key string, curId uint64) (*Alloc, error) {
+	return s.updateBoltAlloc(key, func(alloc *Alloc) error {
+		return advanceAlloc(alloc, curId, time.Now().UnixMilli())
+	})
+}
+
+func (s *BoltStore) DeleteKey(ctx context.Context, key string) error {
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(allocBucket)
+		if b.Get([]byte(key)) == nil {
+			return ErrKeyNotFound
+		}
+		return b.Delete([]byte(key))
+	})
+	if err != nil {
+		if pkgErr, ok := err.(*pkg.Err); ok {
+			return pkgErr
+		}
+		log.Printf("dao bolt delete key err: %v\n", err)
+		return pkg.ErrDb.Message(err.Error())
+	}
+
+	return nil
+}
+
+func (s *BoltStore) CreateUpdate(ctx context.Context, alloc *Alloc) error {
+	if alloc == nil {
+		return ErrNilAlloc
+	}
+
+	if alloc.Step == 0 {
+		alloc.Step = defaultStep
+	}
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(allocBucket)
+		old, err := getBoltAlloc(b, alloc.Key)
+		if err != nil {
+			return err
+		}
+
+		return putBoltAlloc(b, mergeAlloc(old, alloc, time.Now().UnixMilli()))
+	})
+	if err != nil {
+		log.Printf("dao bolt create update err: %v\n", err)
+		return pkg.ErrDb.Message(err.Error())
+	}
+
+	return nil
+}
+
+func (s *BoltStore) TakeIdForKey(ctx context.Context, key string, newStep uint32) (*TakeIdResult, error) {
+	return s.takeIdForKey(key, newStep, false)
+}
+
+func (s *BoltStore) LeaseRange(ctx context.Context, key string, size uint32) (*TakeIdResult, error) {
+	return s.takeIdForKey(key, size, true)
+}
+
+func (s *BoltStore) takeIdForKey(key string, newStep uint32, keepStep bool) (*TakeIdResult, error) {
+	var res *TakeIdResult
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(allocBucket)
+		alloc, err := getBoltAlloc(b, key)
+		if err != nil {
+			return err
+		}
+
+		alloc, res, err = takeAlloc(alloc, key, newStep, keepStep, time.Now().UnixMilli())
+		if err != nil {
+			return err
+		}
+		return putBoltAlloc(b, alloc)
+	})
+	if err != nil {
+		if pkgErr, ok := err.(*pkg.Err); ok {
+			return nil, pkgErr
+		}
+		log.Printf("dao bolt take id err: %v\n", err)
+		return nil, pkg.ErrDb.Message(err.Error())
+	}
+
+	return res, nil
+}
+
+func workerKey(workerId uint64) []byte {
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, workerId)
+	return k
+}
+
+func getBoltLease(b *bolt.Bucket, workerId uint64) (*WorkerLease, error) {
+	data := b.Get(workerKey(workerId))
+	if data == nil {
+		return nil, nil
+	}
+
+	var lease WorkerLease
+	if err := json.Unmarshal(data, &lease); err != nil {
+		return nil, err
+	}
+	return &lease, nil
+}
+
+func putBoltLease(b *bolt.Bucket, lease *WorkerLease) error {
+	if lease.Id == 0 {
+		id, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+		lease.Id = int64(id)
+	}
+
+	data, err := json.Marshal(lease)
+	if err != nil {
+		return err
+	}
+	return b.Put(workerKey(lease.WorkerId), data)
+}
+
+func (s *BoltStore) ClaimWorker(ctx context.Context, owner string, maxWorkerId uint64, ttl time.Duration) (*WorkerLease, error) {
+	var picked *WorkerLease
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(leaseBucket)
+		leases := make(map[uint64]*WorkerLease)
+		err := b.ForEach(func(k, v []byte) error {
+			var lease WorkerLease
+			if err := json.Unmarshal(v, &lease); err != nil {
+				return err
+			}
+			leases[lease.WorkerId] = &lease
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UnixMilli()
+		picked = pickFreeWorker(leases, maxWorkerId, now)
+		if picked == nil {
+			return ErrNoFreeWorker
+		}
+
+		picked.Owner = owner
+		picked.ExpireAt = now + ttl.Milliseconds()
+		picked.UpdatedAt = now
+		return putBoltLease(b, picked)
+	})
+	if err != nil {
+		if pkgErr, ok := err.(*pkg.Err); ok {
+			return nil, pkgErr
+		}
+		log.Printf("dao bolt claim worker err: %v\n", err)
+		return nil, pkg.ErrDb.Message(err.Error())
+	}
+
+	return picked, nil
+}
+
+func (s *BoltStore) RenewWorker(ctx context.Context, workerId uint64, owner string, ttl time.Duration, lastTs int64) (int64, error) {
+	now := time.Now().UnixMilli()
+	expireAt := now + ttl.Milliseconds()
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(leaseBucket)
+		lease, err := getBoltLease(b, workerId)
+		if err != nil {
+			return err
+		}
+
+		if err = renewLease(lease, owner, expireAt, lastTs, now); err != nil {
+			return err
+		}
+		return putBoltLease(b, lease)
+	})
+	if err != nil {
+		if pkgErr, ok := err.(*pkg.Err); ok {
+			return 0, pkgErr
+		}
+		log.Printf("dao bolt renew worker err: %v\n", err)
+		return 0, pkg.ErrDb.Message(err.Error())
+	}
+
+	return expireAt, nil
+}
+
+func (s *BoltStore) ReleaseWorker(ctx context.Context, workerId uint64, owner string, lastTs int64) error {
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(leaseBucket)
+		lease, err := getBoltLease(b, workerId)
+		if err != nil {
+			return err
+		}
+
+		if !releaseLease(lease, owner, lastTs, time.Now().UnixMilli()) {
+			return nil
+		}
+		return putBoltLease(b, lease)
+	})
+	if err != nil {
+		log.Printf("dao bolt release worker err: %v\n", err)
+		return pkg.ErrDb.Message(err.Error())
+	}
+
+	return nil
+}
+
+// there is no remote clock for an embedded store, local clock is returned
+func (s *BoltStore) QueryNowMs(ctx context.Context) (int64, error) {
+	return time.Now().UnixMilli(), nil
+}
+
+// numKey is key of reservation in gapless bucket, reservations of a key are sorted by num
+func numKey(key string, num uint64) []byte {
+	k := make([]byte, len(key)+1+8)
+	copy(k, key)
+	binary.BigEndian.PutUint64(k[len(key)+1:], num)
+	return k
+}
+
+func putBoltReservation(b *bolt.Bucket, r *Reservation) error {
+	if r.Id == 0 {
+		id, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+		r.Id = int64(id)
+	}
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return b.Put(numKey(r.Key, r.Num), data)
+}
+
+// updateBoltReservation runs fn on num of key reserved with token, fn returns false to delete it
+func (s *BoltStore) updateBoltReservation(key string, num uint64, token string, fn func(r *Reservation) bool) error {
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(gaplessBucket)
+		data := b.Get(numKey(key, num))
+		if data == nil {
+			return ErrReservationNotFound
+		}
+
+		var r Reservation
+		if err := json.Unmarshal(data, &r); err != nil {
+			return err
+		}
+		if !reserved(&r, token, time.Now().UnixMilli()) {
+			return ErrReservationNotFound
+		}
+
+		if !fn(&r) {
+			return b.Delete(numKey(key, num))
+		}
+		return putBoltReservation(b, &r)
+	})
+	if err != nil {
+		if pkgErr, ok := err.(*pkg.Err); ok {
+			return pkgErr
+		}
+		log.Printf("dao bolt update reservation err: %v\n", err)
+		return pkg.ErrDb.Message(err.Error())
+	}
+
+	return nil
+}
+
+func (s *BoltStore) ReserveNum(ctx context.Context, key string, token string, ttl time.Duration) (*Reservation, error) {
+	var r *Reservation
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		ab := tx.Bucket(allocBucket)
+		alloc, err := getBoltAlloc(ab, key)
+		if err != nil {
+			return err
+		}
+		if alloc == nil {
+			return ErrKeyNotFound
+		}
+
+		now := time.Now().UnixMilli()
+		gb := tx.Bucket(gaplessBucket)
+		prefix := numKey(key, 0)[:len(key)+1]
+		var reusable *Reservation
+		c := gb.Cursor()
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			var cand Reservation
+			if err := json.Unmarshal(v, &cand); err != nil {
+				return err
+			}
+			if cand.ExpireAt < now {
+				reusable = &cand
+				break
+			}
+		}
+
+		r, err = reserveAlloc(alloc, reusable, token, now+ttl.Milliseconds(), now)
+		if err != nil {
+			return err
+		}
+		if reusable == nil {
+			if err = putBoltAlloc(ab, alloc); err != nil {
+				return err
+			}
+		}
+		return putBoltReservation(gb, r)
+	})
+	if err != nil {
+		if pkgErr, ok := err.(*pkg.Err); ok {
+			return nil, pkgErr
+		}
+		log.Printf("dao bolt reserve num err: %v\n", err)
+		return nil, pkg.ErrDb.Message(err.Error())
+	}
+
+	return r, nil
+}
+
+func (s *BoltStore) ConfirmNum(ctx context.Context, key string, num uint64, token string) error {
+	return s.updateBoltReservation(key, num, token, func(r *Reservation) bool {
+		return false
+	})
+}
+
+func (s *BoltStore) ReleaseNum(ctx context.Context, key string, num uint64, token string) error {
+	return s.updateBoltReservation(key, num, token, func(r *Reservation) bool {
+		r.Token = ""
+		r.ExpireAt = 0
+		r.UpdatedAt = time.Now().UnixMilli()
+		return true
+	})
+}
@@ -18,22 +18,38 @@ const (
 	ENV_DB_ADDR = "ENV_DB_ADDR"
 )
 
-var (
+const (
+	mysqlErrDupEntry = 1062
+)
+
+func init() {
+	RegisterStore(StoreMysql, func() (AllocStore, error) {
+		return NewMysqlStore()
+	})
+}
+
+// MysqlStore is the AllocStore and LeaseStore backed by mysql
+type MysqlStore struct {
 	db *sql.DB
+}
+
+var (
+	_ AllocStore   = (*MysqlStore)(nil)
+	_ LeaseStore   = (*MysqlStore)(nil)
+	_ GaplessStore = (*MysqlStore)(nil)
 )
 
-// init db by environment variables
-func InitDB() {
+// init mysql store by environment variables
+func NewMysqlStore() (*MysqlStore, error) {
 	dsn := getDbDsn(
 		os.Getenv(ENV_DB_USER),
 		os.Getenv(ENV_DB_PASS),
 		os.Getenv(ENV_DB_ADDR),
 		os.Getenv(ENV_DB_NAME))
 
-	var err error
-	db, err = sql.Open("mysql", dsn)
+	db, err := sql.Open("mysql", dsn)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 	db.SetMaxOpenConns(100)
 	db.SetMaxIdleConns(100)
@@ -43,24 +59,26 @@ func InitDB() {
 	defer cancel()
 	err = db.PingContext(ctx)
 	if err != nil {
-		panic(fmt.Sprintf("failed to connect db: %v", err))
+		db.Close()
+		return nil, fmt.Errorf("failed to connect db: %v", err)
 	}
 
 	log.Println("db inited")
+	return &MysqlStore{db: db}, nil
 }
 
-func CloseDB() {
-	if db != nil {
-		err := db.Close()
-		if err != nil {
-			log.Printf("can not close db: %v", err)
-		}
+func (s *MysqlStore) Close() error {
+	err := s.db.Close()
+	if err != nil {
+		log.Printf("can not close db: %v", err)
+		return err
 	}
 	log.Println("db closed")
+	return nil
 }
 
-func GetDB() *sql.DB {
-	return db
+func (s *MysqlStore) GetDB() *sql.DB {
+	return s.db
 }
 
 func getDbDsn(user, pass, addr, dbName string) string {
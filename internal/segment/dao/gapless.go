@@ -0,0 +1,164 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ryanreadbooks/folium/internal/pkg"
+	"google.golang.org/grpc/codes"
+)
+
+// gapless reservation table, a row is a number of a gapless key which is reserved and not confirmed yet,
+// or which is released or expired and waits to be reserved again. The row is deleted once its number is confirmed
+const (
+	GaplessTableName = "gapless_reservation"
+)
+
+var (
+	ErrKeyNotGapless       = pkg.NewErr(int(codes.FailedPrecondition), "key is not gapless")
+	ErrReservationNotFound = pkg.NewErr(int(codes.NotFound), "reservation is not found or expired")
+)
+
+// dao Reservation instance representation
+type Reservation struct {
+	Id        int64  // id primary key
+	Key       string // biz_key, unique key with num
+	Num       uint64 // num
+	Token     string // token, only the holder of token can confirm or release the reservation
+	ExpireAt  int64  // expire_at, the number can be reserved again once it expires, zero if it is released
+	CreatedAt int64  // created_at
+	UpdatedAt int64  // updated_at
+}
+
+// GaplessStore keeps the reservations of gapless keys, an AllocStore can optionally implement it
+type GaplessStore interface {
+	// ReserveNum reserves the smallest released or expired number of gapless key for ttl,
+	// a new number is taken from cur_id if there is none. ErrKeyNotFound, ErrKeyDisabled
+	// and ErrKeyNotGapless are returned if key is not found, disabled or not gapless
+	ReserveNum(ctx context.Context, key string, token string, ttl time.Duration) (*Reservation, error)
+
+	// ConfirmNum makes the reserved num of key permanent,
+	// ErrReservationNotFound is returned if num is not reserved with token or the reservation expired
+	ConfirmNum(ctx context.Context, key string, num uint64, token string) error
+
+	// ReleaseNum gives the reserved num of key back so that it is reserved again before any new number,
+	// ErrReservationNotFound is returned if num is not reserved with token or the reservation expired
+	ReleaseNum(ctx context.Context, key string, num uint64, token string) error
+}
+
+func (s *MysqlStore) ReserveNum(ctx context.Context, key string, token string, ttl time.Duration) (*Reservation, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("dao reserve num begin tx err: %v\n", err)
+		return nil, pkg.ErrDb.Message(err.Error())
+	}
+
+	var rollback = true
+	defer func() {
+		if rollback {
+			tx.Rollback()
+		}
+	}()
+
+	// reservations of key are serialized by the lock of its alloc row
+	var alloc Alloc
+	row := tx.QueryRowContext(ctx,
+		fmt.Sprintf("select %s from %s where biz_key = ? for update", allocColumns, TableName),
+		key,
+	)
+	if err = scanAlloc(row, &alloc); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrKeyNotFound
+		}
+		log.Printf("dao reserve num query key err: %v\n", err)
+		return nil, pkg.ErrDb.Message(err.Error())
+	}
+
+	if err = checkGapless(&alloc); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	r := &Reservation{Key: key, Token: token, ExpireAt: now + ttl.Milliseconds(), CreatedAt: now, UpdatedAt: now}
+	err = tx.QueryRowContext(ctx,
+		fmt.Sprintf("select num from %s where biz_key = ? and expire_at < ? order by num limit 1 for update", GaplessTableName),
+		key, now,
+	).Scan(&r.Num)
+	if err == nil {
+		// numbers given back are reserved before any new one
+		err = txStmtExec(ctx, tx,
+			fmt.Sprintf("update %s set token = ?, expire_at = ?, updated_at = ? where biz_key = ? and num = ?", GaplessTableName),
+			r.Token, r.ExpireAt, now, key, r.Num,
+		)
+	} else if errors.Is(err, sql.ErrNoRows) {
+		r.Num, err = nextNum(&alloc)
+		if err != nil {
+			return nil, err
+		}
+		err = txStmtExec(ctx, tx,
+			fmt.Sprintf("update %s set cur_id = ?, updated_at = ? where biz_key = ?", TableName),
+			alloc.CurId, now, key,
+		)
+		if err == nil {
+			err = txStmtExec(ctx, tx,
+				fmt.Sprintf(`insert into %s(biz_key, num, token, expire_at, created_at, updated_at)
+				values (?,?,?,?,?,?)`, GaplessTableName),
+				key, r.Num, r.Token, r.ExpireAt, now, now,
+			)
+		}
+	}
+	if err != nil {
+		log.Printf("dao reserve num err: %v\n", err)
+		return nil, pkg.ErrDb.Message(err.Error())
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Printf("dao reserve num commit err: %v\n", err)
+		return nil, pkg.ErrDb.Message(err.Error())
+	}
+	rollback = false
+
+	return r, nil
+}
+
+func (s *MysqlStore) ConfirmNum(ctx context.Context, key string, num uint64, token string) error {
+	statement := fmt.Sprintf(
+		"delete from %s where biz_key = ? and num = ? and token = ? and expire_at >= ?",
+		GaplessTableName,
+	)
+	affected, err := s.stmtExecAffected(ctx, statement, key, num, token, time.Now().UnixMilli())
+	if err != nil {
+		log.Printf("dao confirm num err: %v\n", err)
+		return pkg.ErrDb.Message(err.Error())
+	}
+
+	if affected == 0 {
+		return ErrReservationNotFound
+	}
+
+	return nil
+}
+
+func (s *MysqlStore) ReleaseNum(ctx context.Context, key string, num uint64, token string) error {
+	now := time.Now().UnixMilli()
+	statement := fmt.Sprintf(
+		`update %s set token = '', expire_at = 0, updated_at = ?
+		where biz_key = ? and num = ? and token = ? and expire_at >= ?`,
+		GaplessTableName,
+	)
+	affected, err := s.stmtExecAffected(ctx, statement, now, key, num, token, now)
+	if err != nil {
+		log.Printf("dao release num err: %v\n", err)
+		return pkg.ErrDb.Message(err.Error())
+	}
+
+	if affected == 0 {
+		return ErrReservationNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package dao
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func gaplessStore(t *testing.T) GaplessStore {
+	gs, ok := store.(GaplessStore)
+	if !ok {
+		t.Skipf("store %T does not support gapless key", store)
+	}
+	return gs
+}
+
+func TestReserveNum(t *testing.T) {
+	defer clean()
+	gs := gaplessStore(t)
+
+	err := store.CreateKey(ctx, &Alloc{Key: "test-gapless", Step: 100, Gapless: true})
+	assert.Nil(t, err)
+
+	r1, err := gs.ReserveNum(ctx, "test-gapless", "t1", time.Minute)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1, r1.Num)
+	r2, err := gs.ReserveNum(ctx, "test-gapless", "t2", time.Minute)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 2, r2.Num)
+
+	// only the holder can confirm
+	err = gs.ConfirmNum(ctx, "test-gapless", r1.Num, "t2")
+	assert.Equal(t, ErrReservationNotFound, err)
+	err = gs.ConfirmNum(ctx, "test-gapless", r1.Num, "t1")
+	assert.Nil(t, err)
+	err = gs.ConfirmNum(ctx, "test-gapless", r1.Num, "t1")
+	assert.Equal(t, ErrReservationNotFound, err)
+
+	// released number is reserved again before a new one
+	err = gs.ReleaseNum(ctx, "test-gapless", r2.Num, "t2")
+	assert.Nil(t, err)
+	err = gs.ReleaseNum(ctx, "test-gapless", r2.Num, "t2")
+	assert.Equal(t, ErrReservationNotFound, err)
+	r3, err := gs.ReserveNum(ctx, "test-gapless", "t3", time.Minute)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 2, r3.Num)
+	r4, err := gs.ReserveNum(ctx, "test-gapless", "t4", time.Minute)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 3, r4.Num)
+
+	alloc, err := store.QueryByKey(ctx, "test-gapless")
+	assert.Nil(t, err)
+	assert.True(t, alloc.Gapless)
+	assert.EqualValues(t, 4, alloc.CurId)
+}
+
+func TestReserveNum_expired(t *testing.T) {
+	defer clean()
+	gs := gaplessStore(t)
+
+	err := store.CreateKey(ctx, &Alloc{Key: "test-gapless", Step: 100, Gapless: true})
+	assert.Nil(t, err)
+
+	r1, err := gs.ReserveNum(ctx, "test-gapless", "t1", time.Millisecond*10)
+	assert.Nil(t, err)
+	time.Sleep(time.Millisecond * 30)
+
+	// expired reservation can not be confirmed and its number is reserved again
+	err = gs.ConfirmNum(ctx, "test-gapless", r1.Num, "t1")
+	assert.Equal(t, ErrReservationNotFound, err)
+	r2, err := gs.ReserveNum(ctx, "test-gapless", "t2", time.Minute)
+	assert.Nil(t, err)
+	assert.Equal(t, r1.Num, r2.Num)
+	assert.Nil(t, gs.ConfirmNum(ctx, "test-gapless", r2.Num, "t2"))
+}
+
+func TestReserveNum_notGapless(t *testing.T) {
+	defer clean()
+	gs := gaplessStore(t)
+
+	_, err := gs.ReserveNum(ctx, "test-none", "t1", time.Minute)
+	assert.Equal(t, ErrKeyNotFound, err)
+
+	err = store.CreateKey(ctx, &Alloc{Key: "test-biz", Step: 100})
+	assert.Nil(t, err)
+	_, err = gs.ReserveNum(ctx, "test-biz", "t1", time.Minute)
+	assert.Equal(t, ErrKeyNotGapless, err)
+
+	err = store.CreateKey(ctx, &Alloc{Key: "test-gapless", Step: 100, Gapless: true})
+	assert.Nil(t, err)
+	_, err = store.TakeIdForKey(ctx, "test-gapless", 100)
+	assert.Equal(t, ErrKeyGapless, err)
+}
@@ -0,0 +1,198 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/ryanreadbooks/folium/internal/pkg"
+	"google.golang.org/grpc/codes"
+)
+
+// worker lease table
+const (
+	LeaseTableName = "worker_lease"
+	leaseColumns   = "id, worker_id, owner, expire_at, last_ts, created_at, updated_at"
+
+	claimRetries = 3
+)
+
+var (
+	ErrNoFreeWorker = pkg.NewErr(int(codes.ResourceExhausted), "no free worker id")
+	ErrLeaseLost    = pkg.NewErr(int(codes.FailedPrecondition), "worker lease is lost")
+)
+
+// dao WorkerLease instance representation
+type WorkerLease struct {
+	Id        int64  // id primary key
+	WorkerId  uint64 // worker_id unique key
+	Owner     string // owner
+	ExpireAt  int64  // expire_at
+	LastTs    int64  // last_ts
+	CreatedAt int64  // created_at
+	UpdatedAt int64  // updated_at
+}
+
+func (s *MysqlStore) ClaimWorker(ctx context.Context, owner string, maxWorkerId uint64, ttl time.Duration) (*WorkerLease, error) {
+	var err error
+	for i := 0; i < claimRetries; i++ {
+		var lease *WorkerLease
+		lease, err = s.claimWorker(ctx, owner, maxWorkerId, ttl)
+		if err == nil {
+			return lease, nil
+		}
+
+		var myErr *mysql.MySQLError
+		if !errors.As(err, &myErr) || myErr.Number != mysqlErrDupEntry {
+			break
+		}
+		// another node inserted the same worker id, try again
+	}
+
+	if pkgErr, ok := err.(*pkg.Err); ok {
+		return nil, pkgErr
+	}
+	log.Printf("dao claim worker err: %v\n", err)
+	return nil, pkg.ErrDb.Message(err.Error())
+}
+
+func (s *MysqlStore) claimWorker(ctx context.Context, owner string, maxWorkerId uint64, ttl time.Duration) (*WorkerLease, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var rollback = true
+	defer func() {
+		if rollback {
+			tx.Rollback()
+		}
+	}()
+
+	rows, err := tx.QueryContext(ctx,
+		fmt.Sprintf("select %s from %s where worker_id <= ? order by worker_id for update", leaseColumns, LeaseTableName),
+		maxWorkerId,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	leases := make(map[uint64]*WorkerLease)
+	for rows.Next() {
+		var lease WorkerLease
+		err = rows.Scan(&lease.Id,
+			&lease.WorkerId,
+			&lease.Owner,
+			&lease.ExpireAt,
+			&lease.LastTs,
+			&lease.CreatedAt,
+			&lease.UpdatedAt)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		leases[lease.WorkerId] = &lease
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	picked := pickFreeWorker(leases, maxWorkerId, now)
+	if picked == nil {
+		return nil, ErrNoFreeWorker
+	}
+
+	picked.Owner = owner
+	picked.ExpireAt = now + ttl.Milliseconds()
+	picked.UpdatedAt = now
+
+	statement := `
+		insert into %s(worker_id, owner, expire_at, created_at, updated_at)
+		values (?,?,?,?,?) as new_vals
+		on duplicate key update
+		owner = new_vals.owner,
+		expire_at = new_vals.expire_at,
+		updated_at = new_vals.updated_at
+	`
+	statement = fmt.Sprintf(statement, LeaseTableName)
+	err = txStmtExec(ctx, tx, statement,
+		picked.WorkerId, picked.Owner, picked.ExpireAt, picked.CreatedAt, picked.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	rollback = false
+
+	return picked, nil
+}
+
+// pick the smallest worker id which is free at unix ms now, nil is returned if there is none
+func pickFreeWorker(leases map[uint64]*WorkerLease, maxWorkerId uint64, now int64) *WorkerLease {
+	for wid := uint64(0); wid <= maxWorkerId; wid++ {
+		lease, ok := leases[wid]
+		if !ok {
+			return &WorkerLease{WorkerId: wid, CreatedAt: now}
+		}
+		if lease.ExpireAt < now {
+			return lease
+		}
+	}
+
+	return nil
+}
+
+func (s *MysqlStore) RenewWorker(ctx context.Context, workerId uint64, owner string, ttl time.Duration, lastTs int64) (int64, error) {
+	now := time.Now().UnixMilli()
+	expireAt := now + ttl.Milliseconds()
+	statement := fmt.Sprintf(
+		`update %s set expire_at = ?, last_ts = greatest(last_ts, ?), updated_at = ?
+		where worker_id = ? and owner = ? and expire_at >= ?`,
+		LeaseTableName,
+	)
+	affected, err := s.stmtExecAffected(ctx, statement, expireAt, lastTs, now, workerId, owner, now)
+	if err != nil {
+		log.Printf("dao renew worker err: %v\n", err)
+		return 0, pkg.ErrDb.Message(err.Error())
+	}
+
+	if affected == 0 {
+		// the lease is expired or taken by others
+		return 0, ErrLeaseLost
+	}
+
+	return expireAt, nil
+}
+
+func (s *MysqlStore) ReleaseWorker(ctx context.Context, workerId uint64, owner string, lastTs int64) error {
+	now := time.Now().UnixMilli()
+	statement := fmt.Sprintf(
+		"update %s set expire_at = 0, last_ts = greatest(last_ts, ?), updated_at = ? where worker_id = ? and owner = ?",
+		LeaseTableName,
+	)
+	_, err := s.stmtExecAffected(ctx, statement, lastTs, now, workerId, owner)
+	if err != nil {
+		log.Printf("dao release worker err: %v\n", err)
+		return pkg.ErrDb.Message(err.Error())
+	}
+
+	return nil
+}
+
+func (s *MysqlStore) QueryNowMs(ctx context.Context) (int64, error) {
+	var now int64
+	err := s.db.QueryRowContext(ctx, "select cast(unix_timestamp(now(3)) * 1000 as signed)").Scan(&now)
+	if err != nil {
+		log.Printf("dao query now err: %v\n", err)
+		return 0, pkg.ErrDb.Message(err.Error())
+	}
+
+	return now, nil
+}
@@ -0,0 +1,72 @@
+package dao
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func leaseStore(t *testing.T) LeaseStore {
+	ls, ok := store.(LeaseStore)
+	if !ok {
+		t.Skipf("store %T does not support worker lease", store)
+	}
+	return ls
+}
+
+func TestClaimWorker(t *testing.T) {
+	defer clean()
+	store := leaseStore(t)
+
+	l1, err := store.ClaimWorker(ctx, "node-1", 1, time.Minute)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 0, l1.WorkerId)
+
+	l2, err := store.ClaimWorker(ctx, "node-2", 1, time.Minute)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1, l2.WorkerId)
+
+	_, err = store.ClaimWorker(ctx, "node-3", 1, time.Minute)
+	assert.Equal(t, ErrNoFreeWorker, err)
+
+	// released worker id can be claimed again
+	err = store.ReleaseWorker(ctx, l1.WorkerId, "node-1", 100)
+	assert.Nil(t, err)
+	l3, err := store.ClaimWorker(ctx, "node-3", 1, time.Minute)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 0, l3.WorkerId)
+	assert.EqualValues(t, 100, l3.LastTs)
+}
+
+func TestRenewWorker(t *testing.T) {
+	defer clean()
+	store := leaseStore(t)
+
+	l, err := store.ClaimWorker(ctx, "node-1", 10, time.Millisecond*100)
+	assert.Nil(t, err)
+
+	expireAt, err := store.RenewWorker(ctx, l.WorkerId, "node-1", time.Minute, 200)
+	assert.Nil(t, err)
+	assert.Greater(t, expireAt, l.ExpireAt)
+
+	_, err = store.RenewWorker(ctx, l.WorkerId, "node-2", time.Minute, 300)
+	assert.Equal(t, ErrLeaseLost, err)
+
+	// last ts never goes back
+	err = store.ReleaseWorker(ctx, l.WorkerId, "node-1", 100)
+	assert.Nil(t, err)
+	_, err = store.RenewWorker(ctx, l.WorkerId, "node-1", time.Minute, 400)
+	assert.Equal(t, ErrLeaseLost, err)
+
+	l, err = store.ClaimWorker(ctx, "node-2", 10, time.Minute)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 200, l.LastTs)
+}
+
+func TestQueryNowMs(t *testing.T) {
+	store := leaseStore(t)
+	now, err := store.QueryNowMs(ctx)
+	assert.Nil(t, err)
+	assert.InDelta(t, time.Now().UnixMilli(), now, float64(time.Minute.Milliseconds()))
+}
@@ -0,0 +1,518 @@
+package dao
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ryanreadbooks/folium/internal/pkg"
+)
+
+const (
+	// StoreMemory names the memory store in tests, it is not registered
+	// so that a deployment never loses its cur_ids by a restart
+	StoreMemory = "memory"
+)
+
+// MemoryHook is called with the name of the store method before the method runs,
+// the method fails with the returned error if it is not nil
+type MemoryHook func(ctx context.Context, op string) error
+
+// MemoryStore is the AllocStore and LeaseStore which keeps all the records in memory,
+// nothing survives a restart so it is only meant for tests and it can not be opened by OpenStore.
+//
+// Latency and errors can be injected by SetHook.
+type MemoryStore struct {
+	mu     sync.Mutex
+	allocs map[string]*Alloc
+	leases map[uint64]*WorkerLease
+	nums   map[string]map[uint64]*Reservation // reservations of gapless keys
+	nextId int64
+
+	hookMu sync.RWMutex
+	hook   MemoryHook
+}
+
+var (
+	_ AllocStore   = (*MemoryStore)(nil)
+	_ LeaseStore   = (*MemoryStore)(nil)
+	_ GaplessStore = (*MemoryStore)(nil)
+)
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		allocs: make(map[string]*Alloc),
+		leases: make(map[uint64]*WorkerLease),
+		nums:   make(map[string]map[uint64]*Reservation),
+	}
+}
+
+// SetHook replaces the hook of store, nil removes it
+func (s *MemoryStore) SetHook(hook MemoryHook) {
+	s.hookMu.Lock()
+	defer s.hookMu.Unlock()
+	s.hook = hook
+}
+
+// Reset drops all the records and the hook
+func (s *MemoryStore) Reset() {
+	s.SetHook(nil)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.allocs = make(map[string]*Alloc)
+	s.leases = make(map[uint64]*WorkerLease)
+	s.nums = make(map[string]map[uint64]*Reservation)
+	s.nextId = 0
+}
+
+// WithLatency returns a hook which delays every op by d
+func WithLatency(d time.Duration) MemoryHook {
+	return func(ctx context.Context, op string) error {
+		select {
+		case <-time.After(d):
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// FailOn returns a hook which makes ops fail with err, all ops fail if no op is given
+func FailOn(err error, ops ...string) MemoryHook {
+	return func(ctx context.Context, op string) error {
+		if len(ops) == 0 {
+			return err
+		}
+		for _, o := range ops {
+			if o == op {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// run hook outside of the lock so that latency does not serialize callers
+func (s *MemoryStore) before(ctx context.Context, op string) error {
+	if err := ctx.Err(); err != nil {
+		return pkg.ErrDb.Message(err.Error())
+	}
+
+	s.hookMu.RLock()
+	hook := s.hook
+	s.hookMu.RUnlock()
+	if hook == nil {
+		return nil
+	}
+
+	if err := hook(ctx, op); err != nil {
+		if pkgErr, ok := err.(*pkg.Err); ok {
+			return pkgErr
+		}
+		return pkg.ErrDb.Message(err.Error())
+	}
+	return nil
+}
+
+func (s *MemoryStore) genId() int64 {
+	s.nextId++
+	return s.nextId
+}
+
+func (s *MemoryStore) Close() error {
+	return nil
+}
+
+func (s *MemoryStore) QueryByKey(ctx context.Context, key string) (*Alloc, error) {
+	if err := s.before(ctx, "QueryByKey"); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	alloc, ok := s.allocs[key]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+
+	cp := *alloc
+	return &cp, nil
+}
+
+func (s *MemoryStore) QueryAll(ctx context.Context) ([]*Alloc, error) {
+	if err := s.before(ctx, "QueryAll"); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	allocs := make([]*Alloc, 0, len(s.allocs))
+	for _, alloc := range s.allocs {
+		cp := *alloc
+		allocs = append(allocs, &cp)
+	}
+	sort.Slice(allocs, func(i, j int) bool { return allocs[i].Id < allocs[j].Id })
+
+	return allocs, nil
+}
+
+func (s *MemoryStore) QueryAllKeys(ctx context.Context) ([]string, error) {
+	if err := s.before(ctx, "QueryAllKeys"); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys := make([]string, 0, len(s.allocs))
+	for key := range s.allocs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+func (s *MemoryStore) QueryPage(ctx context.Context, afterKey string, limit int) ([]*Alloc, error) {
+	if err := s.before(ctx, "QueryPage"); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	allocs := make([]*Alloc, 0, len(s.allocs))
+	for _, alloc := range s.allocs {
+		allocs = append(allocs, alloc)
+	}
+	sort.Slice(allocs, func(i, j int) bool { return allocs[i].Key < allocs[j].Key })
+
+	page := pageAllocs(allocs, afterKey, limit)
+	res := make([]*Alloc, 0, len(page))
+	for _, alloc := range page {
+		cp := *alloc
+		res = append(res, &cp)
+	}
+
+	return res, nil
+}
+
+func (s *MemoryStore) CreateKey(ctx context.Context, alloc *Alloc) error {
+	if alloc == nil {
+		return ErrNilAlloc
+	}
+
+	if err := s.before(ctx, "CreateKey"); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.allocs[alloc.Key]; ok {
+		return ErrKeyExists
+	}
+
+	created := createAlloc(alloc, time.Now().UnixMilli())
+	created.Id = s.genId()
+	s.allocs[alloc.Key] = created
+
+	return nil
+}
+
+func (s *MemoryStore) UpdateStep(ctx context.Context, key string, step, minStep, maxStep uint32) error {
+	if err := s.before(ctx, "UpdateStep"); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	alloc, ok := s.allocs[key]
+	if !ok {
+		return ErrKeyNotFound
+	}
+
+	alloc.Step, alloc.MinStep, alloc.MaxStep = step, minStep, maxStep
+	alloc.UpdatedAt = time.Now().UnixMilli()
+
+	return nil
+}
+
+func (s *MemoryStore) UpdateFormat(ctx context.Context, key string, format string) error {
+	if err := s.before(ctx, "UpdateFormat"); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	alloc, ok := s.allocs[key]
+	if !ok {
+		return ErrKeyNotFound
+	}
+
+	alloc.Format = format
+	alloc.UpdatedAt = time.Now().UnixMilli()
+
+	return nil
+}
+
+func (s *MemoryStore) SetDisabled(ctx context.Context, key string, disabled bool) error {
+	if err := s.before(ctx, "SetDisabled"); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	alloc, ok := s.allocs[key]
+	if !ok {
+		return ErrKeyNotFound
+	}
+
+	alloc.Disabled = disabled
+	alloc.UpdatedAt = time.Now().UnixMilli()
+
+	return nil
+}
+
+func (s *MemoryStore) DeleteKey(ctx context.Context, key string) error {
+	if err := s.before(ctx, "DeleteKey"); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.allocs[key]; !ok {
+		return ErrKeyNotFound
+	}
+	delete(s.allocs, key)
+
+	return nil
+}
+
+func (s *MemoryStore) AdvanceCurId(ctx context.Context, key string, curId uint64) (*Alloc, error) {
+	if err := s.before(ctx, "AdvanceCurId"); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	alloc, ok := s.allocs[key]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+
+	if err := advanceAlloc(alloc, curId, time.Now().UnixMilli()); err != nil {
+		return nil, err
+	}
+
+	cp := *alloc
+	return &cp, nil
+}
+
+func (s *MemoryStore) CreateUpdate(ctx context.Context, alloc *Alloc) error {
+	if alloc == nil {
+		return ErrNilAlloc
+	}
+
+	if alloc.Step == 0 {
+		alloc.Step = defaultStep
+	}
+
+	if err := s.before(ctx, "CreateUpdate"); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	merged := mergeAlloc(s.allocs[alloc.Key], alloc, time.Now().UnixMilli())
+	if merged.Id == 0 {
+		merged.Id = s.genId()
+	}
+	s.allocs[alloc.Key] = merged
+
+	return nil
+}
+
+func (s *MemoryStore) TakeIdForKey(ctx context.Context, key string, newStep uint32) (*TakeIdResult, error) {
+	if err := s.before(ctx, "TakeIdForKey"); err != nil {
+		return nil, err
+	}
+
+	return s.takeIdForKey(key, newStep, false)
+}
+
+func (s *MemoryStore) LeaseRange(ctx context.Context, key string, size uint32) (*TakeIdResult, error) {
+	if err := s.before(ctx, "LeaseRange"); err != nil {
+		return nil, err
+	}
+
+	return s.takeIdForKey(key, size, true)
+}
+
+func (s *MemoryStore) takeIdForKey(key string, newStep uint32, keepStep bool) (*TakeIdResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	alloc, res, err := takeAlloc(s.allocs[key], key, newStep, keepStep, time.Now().UnixMilli())
+	if err != nil {
+		return nil, err
+	}
+	if alloc.Id == 0 {
+		alloc.Id = s.genId()
+	}
+	s.allocs[key] = alloc
+
+	return res, nil
+}
+
+func (s *MemoryStore) ClaimWorker(ctx context.Context, owner string, maxWorkerId uint64, ttl time.Duration) (*WorkerLease, error) {
+	if err := s.before(ctx, "ClaimWorker"); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	leases := make(map[uint64]*WorkerLease, len(s.leases))
+	for workerId, lease := range s.leases {
+		cp := *lease
+		leases[workerId] = &cp
+	}
+
+	now := time.Now().UnixMilli()
+	picked := pickFreeWorker(leases, maxWorkerId, now)
+	if picked == nil {
+		return nil, ErrNoFreeWorker
+	}
+
+	picked.Owner = owner
+	picked.ExpireAt = now + ttl.Milliseconds()
+	picked.UpdatedAt = now
+	if picked.Id == 0 {
+		picked.Id = s.genId()
+	}
+	cp := *picked
+	s.leases[picked.WorkerId] = &cp
+
+	return picked, nil
+}
+
+func (s *MemoryStore) RenewWorker(ctx context.Context, workerId uint64, owner string, ttl time.Duration, lastTs int64) (int64, error) {
+	if err := s.before(ctx, "RenewWorker"); err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now().UnixMilli()
+	expireAt := now + ttl.Milliseconds()
+	if err := renewLease(s.leases[workerId], owner, expireAt, lastTs, now); err != nil {
+		return 0, err
+	}
+
+	return expireAt, nil
+}
+
+func (s *MemoryStore) ReleaseWorker(ctx context.Context, workerId uint64, owner string, lastTs int64) error {
+	if err := s.before(ctx, "ReleaseWorker"); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	releaseLease(s.leases[workerId], owner, lastTs, time.Now().UnixMilli())
+	return nil
+}
+
+func (s *MemoryStore) QueryNowMs(ctx context.Context) (int64, error) {
+	if err := s.before(ctx, "QueryNowMs"); err != nil {
+		return 0, err
+	}
+
+	return time.Now().UnixMilli(), nil
+}
+
+func (s *MemoryStore) ReserveNum(ctx context.Context, key string, token string, ttl time.Duration) (*Reservation, error) {
+	if err := s.before(ctx, "ReserveNum"); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	alloc, ok := s.allocs[key]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+
+	now := time.Now().UnixMilli()
+	var reusable *Reservation
+	for _, r := range s.nums[key] {
+		if r.ExpireAt < now && (reusable == nil || r.Num < reusable.Num) {
+			reusable = r
+		}
+	}
+
+	r, err := reserveAlloc(alloc, reusable, token, now+ttl.Milliseconds(), now)
+	if err != nil {
+		return nil, err
+	}
+	if r.Id == 0 {
+		r.Id = s.genId()
+		if s.nums[key] == nil {
+			s.nums[key] = make(map[uint64]*Reservation)
+		}
+		s.nums[key][r.Num] = r
+	}
+
+	cp := *r
+	return &cp, nil
+}
+
+func (s *MemoryStore) ConfirmNum(ctx context.Context, key string, num uint64, token string) error {
+	if err := s.before(ctx, "ConfirmNum"); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !reserved(s.nums[key][num], token, time.Now().UnixMilli()) {
+		return ErrReservationNotFound
+	}
+
+	delete(s.nums[key], num)
+	return nil
+}
+
+func (s *MemoryStore) ReleaseNum(ctx context.Context, key string, num uint64, token string) error {
+	if err := s.before(ctx, "ReleaseNum"); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now().UnixMilli()
+	r := s.nums[key][num]
+	if !reserved(r, token, now) {
+		return ErrReservationNotFound
+	}
+
+	r.Token = ""
+	r.ExpireAt = 0
+	r.UpdatedAt = now
+	return nil
+}
@@ -0,0 +1,40 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ryanreadbooks/folium/internal/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStoreHook(t *testing.T) {
+	s := NewMemoryStore()
+
+	s.SetHook(FailOn(errors.New("db down"), "TakeIdForKey"))
+	_, err := s.TakeIdForKey(ctx, "test_biz", 10)
+	assert.Equal(t, pkg.ErrDb.Code, err.(*pkg.Err).Code)
+
+	// other ops are not affected
+	err = s.CreateUpdate(ctx, &Alloc{Key: "test_biz", CurId: 1, Step: 10})
+	assert.Nil(t, err)
+
+	// failed op does not advance cur_id
+	s.SetHook(nil)
+	res, err := s.TakeIdForKey(ctx, "test_biz", 10)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1, res.Begin)
+
+	s.SetHook(WithLatency(time.Second))
+	tctx, cancel := context.WithTimeout(ctx, time.Millisecond*20)
+	defer cancel()
+	_, err = s.QueryByKey(tctx, "test_biz")
+	assert.NotNil(t, err)
+
+	s.Reset()
+	keys, err := s.QueryAllKeys(ctx)
+	assert.Nil(t, err)
+	assert.Empty(t, keys)
+}
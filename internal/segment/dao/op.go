@@ -8,28 +8,23 @@ import (
 	"log"
 	"time"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/ryanreadbooks/folium/internal/pkg"
 )
 
-var (
-	ErrNilAlloc = pkg.ErrInvalidArgs.Message("alloc arg is nil")
-)
-
-func QueryByKey(ctx context.Context, key string) (*Alloc, error) {
+func (s *MysqlStore) QueryByKey(ctx context.Context, key string) (*Alloc, error) {
 	var alloc Alloc
 	query := fmt.Sprintf(
 		`select %s from %s where biz_key = ?`,
 		allocColumns,
 		TableName,
 	)
-	row := db.QueryRowContext(ctx, query, key)
-	err := row.Scan(&alloc.Id,
-		&alloc.Key,
-		&alloc.CurId,
-		&alloc.Step,
-		&alloc.CreatedAt,
-		&alloc.UpdatedAt)
+	row := s.db.QueryRowContext(ctx, query, key)
+	err := scanAlloc(row, &alloc)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrKeyNotFound
+		}
 		log.Printf("dao query row err: %v\n", err)
 		return nil, pkg.ErrDb.Message(err.Error())
 	}
@@ -38,12 +33,12 @@ func QueryByKey(ctx context.Context, key string) (*Alloc, error) {
 }
 
 // QueryAll retrieves all the alloc records from db
-func QueryAll(ctx context.Context) ([]*Alloc, error) {
+func (s *MysqlStore) QueryAll(ctx context.Context) ([]*Alloc, error) {
 	query := fmt.Sprintf(
 		`select %s from %s`, allocColumns, TableName,
 	)
 
-	rows, err := db.QueryContext(ctx, query)
+	rows, err := s.db.QueryContext(ctx, query)
 	if err != nil {
 		log.Printf("dao query all rows err: %v\n", err)
 		return nil, pkg.ErrDb.Message(err.Error())
@@ -53,12 +48,7 @@ func QueryAll(ctx context.Context) ([]*Alloc, error) {
 	var allocs []*Alloc
 	for rows.Next() {
 		var alloc Alloc
-		err := rows.Scan(&alloc.Id,
-			&alloc.Key,
-			&alloc.CurId,
-			&alloc.Step,
-			&alloc.CreatedAt,
-			&alloc.UpdatedAt)
+		err := scanAlloc(rows, &alloc)
 		if err != nil {
 			log.Printf("dao query all rows scan err: %v\n", err)
 			return nil, pkg.ErrDb.Message(err.Error())
@@ -73,9 +63,9 @@ func QueryAll(ctx context.Context) ([]*Alloc, error) {
 	return allocs, nil
 }
 
-func QueryAllKeys(ctx context.Context) ([]string, error) {
+func (s *MysqlStore) QueryAllKeys(ctx context.Context) ([]string, error) {
 	query := fmt.Sprintf("select biz_key from %s", TableName)
-	rows, err := db.QueryContext(ctx, query)
+	rows, err := s.db.QueryContext(ctx, query)
 	if err != nil {
 		log.Printf("dao query all keys err: %v\n", err)
 		return nil, pkg.ErrDb.Message(err.Error())
@@ -100,8 +90,136 @@ func QueryAllKeys(ctx context.Context) ([]string, error) {
 	return keys, nil
 }
 
+// QueryPage retrieves at most limit alloc records after afterKey in key order
+func (s *MysqlStore) QueryPage(ctx context.Context, afterKey string, limit int) ([]*Alloc, error) {
+	query := fmt.Sprintf(
+		`select %s from %s where biz_key > ? order by biz_key limit ?`, allocColumns, TableName,
+	)
+
+	rows, err := s.db.QueryContext(ctx, query, afterKey, limit)
+	if err != nil {
+		log.Printf("dao query page err: %v\n", err)
+		return nil, pkg.ErrDb.Message(err.Error())
+	}
+	defer rows.Close()
+
+	var allocs []*Alloc
+	for rows.Next() {
+		var alloc Alloc
+		err := scanAlloc(rows, &alloc)
+		if err != nil {
+			log.Printf("dao query page scan err: %v\n", err)
+			return nil, pkg.ErrDb.Message(err.Error())
+		}
+		allocs = append(allocs, &alloc)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, pkg.ErrDb.Message(err.Error())
+	}
+
+	return allocs, nil
+}
+
+// CreateKey inserts the alloc of a new key
+func (s *MysqlStore) CreateKey(ctx context.Context, alloc *Alloc) error {
+	if alloc == nil {
+		return ErrNilAlloc
+	}
+
+	created := createAlloc(alloc, time.Now().UnixMilli())
+	statement := fmt.Sprintf(
+		`insert into %s(biz_key, cur_id, step, min_step, max_step, min_id, max_id, exhaust_policy,
+		reset_period, time_zone, id_format, obfuscate_seed, gapless, disabled, created_at, updated_at)
+		values (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`,
+		TableName,
+	)
+	err := s.stmtExec(ctx, statement, created.Key, created.CurId, created.Step, created.MinStep, created.MaxStep,
+		created.MinId, created.MaxId, created.Exhaust, created.Period, created.TimeZone, created.Format, created.Seed, created.Gapless, created.Disabled,
+		created.CreatedAt, created.UpdatedAt)
+	if err != nil {
+		var myErr *mysql.MySQLError
+		if errors.As(err, &myErr) && myErr.Number == mysqlErrDupEntry {
+			return ErrKeyExists
+		}
+		log.Printf("dao create key err: %v\n", err)
+		return pkg.ErrDb.Message(err.Error())
+	}
+
+	return nil
+}
+
+func (s *MysqlStore) UpdateStep(ctx context.Context, key string, step, minStep, maxStep uint32) error {
+	return s.updateKey(ctx, key, "step = ?, min_step = ?, max_step = ?", step, minStep, maxStep)
+}
+
+func (s *MysqlStore) UpdateFormat(ctx context.Context, key string, format string) error {
+	return s.updateKey(ctx, key, "id_format = ?", format)
+}
+
+func (s *MysqlStore) SetDisabled(ctx context.Context, key string, disabled bool) error {
+	return s.updateKey(ctx, key, "disabled = ?", disabled)
+}
+
+func (s *MysqlStore) AdvanceCurId(ctx context.Context, key string, curId uint64) (*Alloc, error) {
+	statement := fmt.Sprintf(
+		"update %s set cur_id = ?, updated_at = ? where biz_key = ? and cur_id < ? and (max_id = 0 or ? <= max_id + 1)",
+		TableName,
+	)
+	_, err := s.stmtExecAffected(ctx, statement, curId, time.Now().UnixMilli(), key, curId, curId)
+	if err != nil {
+		log.Printf("dao advance cur id err: %v\n", err)
+		return nil, pkg.ErrDb.Message(err.Error())
+	}
+
+	// no row is updated if cur_id is already beyond curId or curId is beyond max_id
+	alloc, err := s.QueryByKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if !curIdInBounds(alloc, curId) {
+		return nil, ErrCurIdTooHigh
+	}
+
+	return alloc, nil
+}
+
+func (s *MysqlStore) DeleteKey(ctx context.Context, key string) error {
+	statement := fmt.Sprintf("delete from %s where biz_key = ?", TableName)
+	affected, err := s.stmtExecAffected(ctx, statement, key)
+	if err != nil {
+		log.Printf("dao delete key err: %v\n", err)
+		return pkg.ErrDb.Message(err.Error())
+	}
+
+	if affected == 0 {
+		return ErrKeyNotFound
+	}
+
+	return nil
+}
+
+// updateKey sets columns of key, ErrKeyNotFound is returned if key does not exist
+func (s *MysqlStore) updateKey(ctx context.Context, key string, set string, args ...interface{}) error {
+	statement := fmt.Sprintf("update %s set %s, updated_at = ? where biz_key = ?", TableName, set)
+	args = append(args, time.Now().UnixMilli(), key)
+	affected, err := s.stmtExecAffected(ctx, statement, args...)
+	if err != nil {
+		log.Printf("dao update key err: %v\n", err)
+		return pkg.ErrDb.Message(err.Error())
+	}
+
+	if affected == 0 {
+		// mysql reports no affected row if nothing is changed, make sure key exists
+		_, err = s.QueryByKey(ctx, key)
+		return err
+	}
+
+	return nil
+}
+
 // create or update the alloc given the specific key
-func CreateUpdate(ctx context.Context, alloc *Alloc) error {
+func (s *MysqlStore) CreateUpdate(ctx context.Context, alloc *Alloc) error {
 	if alloc == nil {
 		return ErrNilAlloc
 	}
@@ -111,30 +229,37 @@ func CreateUpdate(ctx context.Context, alloc *Alloc) error {
 	}
 
 	statement := `
-		insert into %s(biz_key, cur_id, step, created_at, updated_at)
-		values (?,?,?,?,?) as new_vals
+		insert into %s(biz_key, cur_id, step, min_step, max_step, min_id, max_id, exhaust_policy,
+		reset_period, time_zone, id_format, obfuscate_seed, gapless, created_at, updated_at)
+		values (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?) as new_vals
 		on duplicate key update
 		cur_id = %s.cur_id + new_vals.step,
 		step = new_vals.step,
+		min_step = new_vals.min_step,
+		max_step = new_vals.max_step,
 		updated_at = ?
 	`
 
 	statement = fmt.Sprintf(statement, TableName, TableName)
 	now := time.Now().UnixMilli()
-	return stmtExec(ctx, statement, alloc.Key, alloc.CurId, alloc.Step, now, now, now)
-}
-
-type TakeIdResult struct {
-	Begin uint64
-	End   uint64
-	Step  uint32
+	return s.stmtExec(ctx, statement, alloc.Key, alloc.CurId, alloc.Step, alloc.MinStep, alloc.MaxStep,
+		alloc.MinId, alloc.MaxId, alloc.Exhaust, alloc.Period, alloc.TimeZone, alloc.Format, alloc.Seed, alloc.Gapless, now, now, now)
 }
 
 // return curId before update
 // query alloc with specific key, then update the corresponding records
 // [Begin, End) is allowed
-func TakeIdForKey(ctx context.Context, key string, newStep uint32) (*TakeIdResult, error) {
-	tx, err := db.Begin()
+func (s *MysqlStore) TakeIdForKey(ctx context.Context, key string, newStep uint32) (*TakeIdResult, error) {
+	return s.takeIdForKey(ctx, key, newStep, false)
+}
+
+func (s *MysqlStore) LeaseRange(ctx context.Context, key string, size uint32) (*TakeIdResult, error) {
+	return s.takeIdForKey(ctx, key, size, true)
+}
+
+// takeIdForKey advances cur_id of key by newStep, newStep is saved as the step of key unless keepStep is set
+func (s *MysqlStore) takeIdForKey(ctx context.Context, key string, newStep uint32, keepStep bool) (*TakeIdResult, error) {
+	tx, err := s.db.Begin()
 	if err != nil {
 		log.Printf("dao begin tx err: %v\n", err)
 		return nil, pkg.ErrDb.Message(err.Error())
@@ -154,18 +279,23 @@ func TakeIdForKey(ctx context.Context, key string, newStep uint32) (*TakeIdResul
 
 	row, err := tx.QueryContext(
 		ctx,
-		fmt.Sprintf("select cur_id, step from %s where biz_key = ? limit 1 for update", TableName),
+		fmt.Sprintf("select cur_id, step, min_step, max_step, min_id, max_id, exhaust_policy, obfuscate_seed, gapless, disabled from %s where biz_key = ? limit 1 for update", TableName),
 		key,
 	)
 
-	if newStep == 0 {
-		newStep = defaultStep
-	}
-
 	var (
 		// if key is not found in db, the following will be the default retvals
-		curId uint64 = defaultCurId
-		step  uint32 = newStep
+		found    bool
+		curId    uint64 = defaultCurId
+		step     uint32 = defaultStep
+		minStep  uint32
+		maxStep  uint32
+		minId    uint64
+		maxId    uint64
+		exhaust  ExhaustPolicy
+		seed     uint64
+		gapless  bool
+		disabled bool
 	)
 
 	if err != nil {
@@ -175,11 +305,12 @@ func TakeIdForKey(ctx context.Context, key string, newStep uint32) (*TakeIdResul
 		}
 	} else {
 		for row.Next() {
-			err = row.Scan(&curId, &step)
+			err = row.Scan(&curId, &step, &minStep, &maxStep, &minId, &maxId, &exhaust, &seed, &gapless, &disabled)
 			if err != nil {
 				log.Printf("dao scan row err: %v\n", err)
 				return nil, pkg.ErrDb.Message(err.Error())
 			}
+			found = true
 			break
 		}
 
@@ -190,25 +321,52 @@ func TakeIdForKey(ctx context.Context, key string, newStep uint32) (*TakeIdResul
 		row.Close() // close row explicitly
 	}
 
-	// initialization and updating
-	statement := `
-		insert into %s(biz_key, cur_id, step, created_at, updated_at)
-		values (?,?,?,?,?) as new_vals
-		on duplicate key update
-		cur_id = %s.cur_id + new_vals.step,
-		step = new_vals.step,
-		updated_at = ?
-	`
+	if disabled {
+		return nil, ErrKeyDisabled
+	}
+	if gapless {
+		return nil, ErrKeyGapless
+	}
 
-	statement = fmt.Sprintf(statement, TableName, TableName)
+	if step == 0 {
+		step = defaultStep
+	}
+	if newStep == 0 {
+		// keep the current step of key
+		newStep = step
+	}
+	if !keepStep {
+		step = newStep
+	}
+
+	begin, end, err := takeRange(curId, newStep, minId, maxId, exhaust)
+	if err != nil {
+		return nil, err
+	}
 
 	now := time.Now().UnixMilli()
-	// update cur_id = cur_id + step
-	err = txStmtExec(ctx, tx,
-		statement,
-		key, defaultCurId+uint64(newStep), newStep, now, now,
-		now,
-	)
+	if found {
+		// the row is locked, cur_id is set to the end of the range taken
+		err = txStmtExec(ctx, tx,
+			fmt.Sprintf("update %s set cur_id = ?, step = ?, updated_at = ? where biz_key = ?", TableName),
+			end, step, now, key,
+		)
+	} else {
+		// initialization, cur_id is advanced by newStep if the key is inserted by others in the meantime
+		statement := `
+			insert into %s(biz_key, cur_id, step, created_at, updated_at)
+			values (?,?,?,?,?) as new_vals
+			on duplicate key update
+			cur_id = %s.cur_id + ?,
+			step = if(?, %s.step, new_vals.step),
+			updated_at = ?
+		`
+		err = txStmtExec(ctx, tx,
+			fmt.Sprintf(statement, TableName, TableName, TableName),
+			key, end, step, now, now,
+			newStep, keepStep, now,
+		)
+	}
 	if err != nil {
 		log.Printf("dao tx stmt exec err: %v\n", err)
 		return nil, pkg.ErrDb.Message(err.Error())
@@ -216,14 +374,17 @@ func TakeIdForKey(ctx context.Context, key string, newStep uint32) (*TakeIdResul
 
 	rollback = false
 	return &TakeIdResult{
-		Begin: curId,
-		End:   curId + uint64(step),
-		Step:  step,
+		Begin:   begin,
+		End:     end,
+		Step:    step,
+		MinStep: minStep,
+		MaxStep: maxStep,
+		Seed:    seed,
 	}, nil
 }
 
-func stmtExec(ctx context.Context, statement string, args ...interface{}) error {
-	stmt, err := db.PrepareContext(ctx, statement)
+func (s *MysqlStore) stmtExec(ctx context.Context, statement string, args ...interface{}) error {
+	stmt, err := s.db.PrepareContext(ctx, statement)
 	if err != nil {
 		return err
 	}
@@ -237,6 +398,22 @@ func stmtExec(ctx context.Context, statement string, args ...interface{}) error
 	return nil
 }
 
+// same as stmtExec but returns the number of affected rows
+func (s *MysqlStore) stmtExecAffected(ctx context.Context, statement string, args ...interface{}) (int64, error) {
+	stmt, err := s.db.PrepareContext(ctx, statement)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	res, err := stmt.ExecContext(ctx, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
 func txStmtExec(ctx context.Context, tx *sql.Tx, statement string, args ...interface{}) error {
 	stmt, err := tx.PrepareContext(ctx, statement)
 	if err != nil {
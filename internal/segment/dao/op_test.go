@@ -4,25 +4,84 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 
 	"github.com/ryanreadbooks/folium/internal/pkg/misc"
 	"github.com/stretchr/testify/assert"
+	bolt "go.etcd.io/bbolt"
 )
 
+// store backend under test, embedded bolt store is used if not set
+const ENV_TEST_STORE = "ENV_TEST_STORE"
+
 var (
-	ctx = context.TODO()
+	ctx   = context.TODO()
+	store AllocStore
 )
 
 func TestMain(m *testing.M) {
-	InitDB()
-	m.Run()
-	CloseDB()
+	var err error
+	name := os.Getenv(ENV_TEST_STORE)
+	var dir string
+	if name == "" || name == StoreBolt {
+		dir, err = os.MkdirTemp("", "folium-dao")
+		if err == nil {
+			store, err = NewBoltStore(filepath.Join(dir, "test.db"))
+		}
+	} else if name == StoreMemory {
+		store = NewMemoryStore()
+	} else {
+		store, err = OpenStore(name)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "open store %s err: %v\n", name, err)
+		os.RemoveAll(dir)
+		os.Exit(1)
+	}
+
+	code := m.Run()
+	store.Close()
+	os.RemoveAll(dir)
+	os.Exit(code)
 }
 
 func clean() {
-	_, err := GetDB().ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE id>0", TableName))
+	var err error
+	switch s := store.(type) {
+	case *MysqlStore:
+		_, err = s.GetDB().ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE id>0", TableName))
+		if err == nil {
+			_, err = s.GetDB().ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE id>0", LeaseTableName))
+		}
+		if err == nil {
+			_, err = s.GetDB().ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE id>0", GaplessTableName))
+		}
+	case *PgStore:
+		_, err = s.GetDB().ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE id>0", TableName))
+		if err == nil {
+			_, err = s.GetDB().ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE id>0", LeaseTableName))
+		}
+		if err == nil {
+			_, err = s.GetDB().ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE id>0", GaplessTableName))
+		}
+	case *MemoryStore:
+		s.Reset()
+	case *BoltStore:
+		err = s.db.Update(func(tx *bolt.Tx) error {
+			for _, name := range [][]byte{allocBucket, leaseBucket, gaplessBucket} {
+				if err := tx.DeleteBucket(name); err != nil {
+					return err
+				}
+				if _, err := tx.CreateBucket(name); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
 	if err != nil {
 		println(err.Error())
 	}
@@ -31,7 +90,7 @@ func clean() {
 func TestUpdate(t *testing.T) {
 	defer clean()
 
-	err := CreateUpdate(ctx, &Alloc{
+	err := store.CreateUpdate(ctx, &Alloc{
 		Key:   "test_biz",
 		CurId: 10000,
 		Step:  100,
@@ -42,7 +101,7 @@ func TestUpdate(t *testing.T) {
 
 func TestQueryByKey(t *testing.T) {
 	defer clean()
-	err := CreateUpdate(ctx, &Alloc{
+	err := store.CreateUpdate(ctx, &Alloc{
 		Key:   "test_biz",
 		CurId: 10000,
 		Step:  100,
@@ -50,14 +109,14 @@ func TestQueryByKey(t *testing.T) {
 
 	assert.Nil(t, err)
 
-	alloc, err := QueryByKey(ctx, "test_biz")
+	alloc, err := store.QueryByKey(ctx, "test_biz")
 	assert.Nil(t, err)
 	assert.NotNil(t, alloc)
 	assert.EqualValues(t, alloc.CurId, 10000)
 	assert.EqualValues(t, alloc.Step, 100)
 
-	_, err = QueryByKey(ctx, "not-found")
-	t.Log(err)
+	_, err = store.QueryByKey(ctx, "not-found")
+	assert.Equal(t, ErrKeyNotFound, err)
 }
 
 func TestQueryAll(t *testing.T) {
@@ -82,10 +141,10 @@ func TestQueryAll(t *testing.T) {
 	}
 
 	for _, a := range allocData {
-		CreateUpdate(ctx, a)
+		store.CreateUpdate(ctx, a)
 	}
 
-	queries, err := QueryAll(ctx)
+	queries, err := store.QueryAll(ctx)
 	assert.Nil(t, err)
 	assert.EqualValues(t, len(queries), len(allocData))
 	for _, q := range queries {
@@ -96,7 +155,7 @@ func TestQueryAll(t *testing.T) {
 func TestConsumeKey(t *testing.T) {
 	defer clean()
 
-	res, err := TakeIdForKey(ctx, "test-biz", 0)
+	res, err := store.TakeIdForKey(ctx, "test-biz", 0)
 	assert.Nil(t, err)
 	assert.EqualValues(t, res.Begin, defaultCurId)
 	assert.EqualValues(t, res.Step, defaultStep)
@@ -115,7 +174,7 @@ func TestConsumeKey(t *testing.T) {
 			defer wg.Done()
 
 			key := keys[rand.Intn(len(keys))]
-			res, err := TakeIdForKey(ctx, key, 0)
+			res, err := store.TakeIdForKey(ctx, key, 0)
 			assert.Nil(t, err)
 			lk.Lock()
 			curIdMap[key] = append(curIdMap[key], res.Begin)
@@ -132,3 +191,292 @@ func TestConsumeKey(t *testing.T) {
 	}
 
 }
+
+func TestTakeIdForKeyChangeStep(t *testing.T) {
+	defer clean()
+
+	res, err := store.TakeIdForKey(ctx, "test-biz", 100)
+	assert.Nil(t, err)
+	assert.EqualValues(t, defaultCurId, res.Begin)
+	assert.EqualValues(t, defaultCurId+100, res.End)
+
+	// smaller step should not overlap with the previous range
+	res2, err := store.TakeIdForKey(ctx, "test-biz", 10)
+	assert.Nil(t, err)
+	assert.EqualValues(t, res.End, res2.Begin)
+	assert.EqualValues(t, res.End+10, res2.End)
+
+	res3, err := store.TakeIdForKey(ctx, "test-biz", 1000)
+	assert.Nil(t, err)
+	assert.EqualValues(t, res2.End, res3.Begin)
+	assert.EqualValues(t, res2.End+1000, res3.End)
+}
+
+func TestLeaseRange(t *testing.T) {
+	defer clean()
+
+	err := store.CreateKey(ctx, &Alloc{Key: "test-biz", Step: 100})
+	assert.Nil(t, err)
+
+	res, err := store.LeaseRange(ctx, "test-biz", 5000)
+	assert.Nil(t, err)
+	assert.EqualValues(t, defaultCurId, res.Begin)
+	assert.EqualValues(t, defaultCurId+5000, res.End)
+	assert.EqualValues(t, 100, res.Step)
+
+	// step of key is not changed by the lease
+	alloc, err := store.QueryByKey(ctx, "test-biz")
+	assert.Nil(t, err)
+	assert.EqualValues(t, defaultCurId+5000, alloc.CurId)
+	assert.EqualValues(t, 100, alloc.Step)
+
+	res, err = store.TakeIdForKey(ctx, "test-biz", 0)
+	assert.Nil(t, err)
+	assert.EqualValues(t, defaultCurId+5000, res.Begin)
+	assert.EqualValues(t, defaultCurId+5100, res.End)
+
+	// new key is created with the default step
+	_, err = store.LeaseRange(ctx, "test-biz-new", 5000)
+	assert.Nil(t, err)
+	alloc, err = store.QueryByKey(ctx, "test-biz-new")
+	assert.Nil(t, err)
+	assert.EqualValues(t, defaultCurId+5000, alloc.CurId)
+	assert.EqualValues(t, defaultStep, alloc.Step)
+}
+
+func TestTakeIdForKeyStepBounds(t *testing.T) {
+	defer clean()
+
+	err := store.CreateUpdate(ctx, &Alloc{Key: "test-biz", CurId: 1, Step: 100, MinStep: 50, MaxStep: 5000})
+	assert.Nil(t, err)
+
+	res, err := store.TakeIdForKey(ctx, "test-biz", 200)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1, res.Begin)
+	assert.EqualValues(t, 50, res.MinStep)
+	assert.EqualValues(t, 5000, res.MaxStep)
+
+	alloc, err := store.QueryByKey(ctx, "test-biz")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 200, alloc.Step)
+	assert.EqualValues(t, 50, alloc.MinStep)
+	assert.EqualValues(t, 5000, alloc.MaxStep)
+}
+
+func TestCreateKey(t *testing.T) {
+	defer clean()
+
+	err := store.CreateKey(ctx, &Alloc{Key: "test-biz", CurId: 5000, Step: 100, MaxStep: 1000})
+	assert.Nil(t, err)
+
+	err = store.CreateKey(ctx, &Alloc{Key: "test-biz", CurId: 1, Step: 100})
+	assert.Equal(t, ErrKeyExists, err)
+
+	alloc, err := store.QueryByKey(ctx, "test-biz")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 5000, alloc.CurId)
+	assert.EqualValues(t, 100, alloc.Step)
+	assert.EqualValues(t, 1000, alloc.MaxStep)
+
+	res, err := store.TakeIdForKey(ctx, "test-biz", 100)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 5000, res.Begin)
+	assert.EqualValues(t, 5100, res.End)
+}
+
+func TestUpdateStep(t *testing.T) {
+	defer clean()
+
+	err := store.UpdateStep(ctx, "test-biz", 10, 0, 0)
+	assert.Equal(t, ErrKeyNotFound, err)
+
+	err = store.CreateKey(ctx, &Alloc{Key: "test-biz", Step: 100})
+	assert.Nil(t, err)
+	err = store.UpdateStep(ctx, "test-biz", 300, 20, 2000)
+	assert.Nil(t, err)
+
+	alloc, err := store.QueryByKey(ctx, "test-biz")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1, alloc.CurId)
+	assert.EqualValues(t, 300, alloc.Step)
+	assert.EqualValues(t, 20, alloc.MinStep)
+	assert.EqualValues(t, 2000, alloc.MaxStep)
+
+	// zero step keeps the step of key
+	res, err := store.TakeIdForKey(ctx, "test-biz", 0)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1, res.Begin)
+	assert.EqualValues(t, 301, res.End)
+	assert.EqualValues(t, 300, res.Step)
+}
+
+func TestSetDisabled(t *testing.T) {
+	defer clean()
+
+	err := store.SetDisabled(ctx, "test-biz", true)
+	assert.Equal(t, ErrKeyNotFound, err)
+
+	_, err = store.TakeIdForKey(ctx, "test-biz", 100)
+	assert.Nil(t, err)
+	err = store.SetDisabled(ctx, "test-biz", true)
+	assert.Nil(t, err)
+
+	_, err = store.TakeIdForKey(ctx, "test-biz", 100)
+	assert.Equal(t, ErrKeyDisabled, err)
+	alloc, err := store.QueryByKey(ctx, "test-biz")
+	assert.Nil(t, err)
+	assert.True(t, alloc.Disabled)
+	assert.EqualValues(t, 101, alloc.CurId)
+
+	err = store.SetDisabled(ctx, "test-biz", false)
+	assert.Nil(t, err)
+	res, err := store.TakeIdForKey(ctx, "test-biz", 100)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 101, res.Begin)
+}
+
+func TestUpdateFormat(t *testing.T) {
+	defer clean()
+
+	err := store.UpdateFormat(ctx, "test-biz", "ORD{seq:8}")
+	assert.Equal(t, ErrKeyNotFound, err)
+
+	err = store.CreateKey(ctx, &Alloc{Key: "test-biz", Step: 100, Format: "ORD{seq:8}"})
+	assert.Nil(t, err)
+	alloc, err := store.QueryByKey(ctx, "test-biz")
+	assert.Nil(t, err)
+	assert.Equal(t, "ORD{seq:8}", alloc.Format)
+
+	err = store.UpdateFormat(ctx, "test-biz", "")
+	assert.Nil(t, err)
+	alloc, err = store.QueryByKey(ctx, "test-biz")
+	assert.Nil(t, err)
+	assert.Equal(t, "", alloc.Format)
+}
+
+func TestQueryPage(t *testing.T) {
+	defer clean()
+
+	for i := 0; i < 25; i++ {
+		_, err := store.TakeIdForKey(ctx, fmt.Sprintf("test-biz-%02d", i), 10)
+		assert.Nil(t, err)
+	}
+
+	var keys []string
+	after := ""
+	for {
+		allocs, err := store.QueryPage(ctx, after, 10)
+		assert.Nil(t, err)
+		if len(allocs) == 0 {
+			break
+		}
+		assert.LessOrEqual(t, len(allocs), 10)
+		for _, alloc := range allocs {
+			keys = append(keys, alloc.Key)
+		}
+		after = allocs[len(allocs)-1].Key
+	}
+
+	assert.Len(t, keys, 25)
+	for i, key := range keys {
+		assert.Equal(t, fmt.Sprintf("test-biz-%02d", i), key)
+	}
+}
+
+func TestAdvanceCurId(t *testing.T) {
+	defer clean()
+
+	_, err := store.AdvanceCurId(ctx, "test-biz", 100)
+	assert.Equal(t, ErrKeyNotFound, err)
+
+	_, err = store.TakeIdForKey(ctx, "test-biz", 100)
+	assert.Nil(t, err)
+
+	alloc, err := store.AdvanceCurId(ctx, "test-biz", 5000)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 5000, alloc.CurId)
+
+	// cur_id is never lowered
+	alloc, err = store.AdvanceCurId(ctx, "test-biz", 10)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 5000, alloc.CurId)
+
+	res, err := store.TakeIdForKey(ctx, "test-biz", 100)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 5000, res.Begin)
+
+	// cur_id goes max_id+1 at most
+	err = store.CreateKey(ctx, &Alloc{Key: "test-max", Step: 10, MaxId: 100})
+	assert.Nil(t, err)
+	_, err = store.AdvanceCurId(ctx, "test-max", 102)
+	assert.Equal(t, ErrCurIdTooHigh, err)
+	alloc, err = store.AdvanceCurId(ctx, "test-max", 101)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 101, alloc.CurId)
+}
+
+func TestDeleteKey(t *testing.T) {
+	defer clean()
+
+	err := store.DeleteKey(ctx, "test-biz")
+	assert.Equal(t, ErrKeyNotFound, err)
+
+	err = store.CreateKey(ctx, &Alloc{Key: "test-biz", Step: 100})
+	assert.Nil(t, err)
+	err = store.DeleteKey(ctx, "test-biz")
+	assert.Nil(t, err)
+	_, err = store.QueryByKey(ctx, "test-biz")
+	assert.Equal(t, ErrKeyNotFound, err)
+}
+
+func TestTakeIdForKeyMaxId(t *testing.T) {
+	defer clean()
+
+	err := store.CreateKey(ctx, &Alloc{Key: "test-biz", Step: 10, MinId: 10, MaxId: 25})
+	assert.Nil(t, err)
+	err = store.CreateKey(ctx, &Alloc{Key: "test-biz-cycle", Step: 10, MinId: 10, MaxId: 25, Exhaust: ExhaustCycle})
+	assert.Nil(t, err)
+
+	for _, key := range []string{"test-biz", "test-biz-cycle"} {
+		// ids start from min id
+		res, err := store.TakeIdForKey(ctx, key, 0)
+		assert.Nil(t, err)
+		assert.EqualValues(t, 10, res.Begin)
+		assert.EqualValues(t, 20, res.End)
+
+		// range is cut short at max id
+		res, err = store.TakeIdForKey(ctx, key, 0)
+		assert.Nil(t, err)
+		assert.EqualValues(t, 20, res.Begin)
+		assert.EqualValues(t, 26, res.End)
+	}
+
+	_, err = store.TakeIdForKey(ctx, "test-biz", 0)
+	assert.Equal(t, ErrKeyExhausted, err)
+	alloc, err := store.QueryByKey(ctx, "test-biz")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 26, alloc.CurId)
+
+	res, err := store.TakeIdForKey(ctx, "test-biz-cycle", 0)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 10, res.Begin)
+	assert.EqualValues(t, 20, res.End)
+	alloc, err = store.QueryByKey(ctx, "test-biz-cycle")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 20, alloc.CurId)
+	assert.EqualValues(t, ExhaustCycle, alloc.Exhaust)
+}
+
+func TestTakeIdForKeySeed(t *testing.T) {
+	defer clean()
+
+	err := store.CreateKey(ctx, &Alloc{Key: "test-biz", Step: 100, Seed: 12345})
+	assert.Nil(t, err)
+	res, err := store.TakeIdForKey(ctx, "test-biz", 0)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 12345, res.Seed)
+
+	res, err = store.TakeIdForKey(ctx, "test-biz-plain", 0)
+	assert.Nil(t, err)
+	assert.Zero(t, res.Seed)
+}
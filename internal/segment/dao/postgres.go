@@ -0,0 +1,765 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/ryanreadbooks/folium/internal/pkg"
+)
+
+const (
+	StorePostgres = "postgres"
+
+	ENV_DB_SSLMODE = "ENV_DB_SSLMODE"
+
+	pgErrUniqueViolation = "23505"
+)
+
+func init() {
+	RegisterStore(StorePostgres, func() (AllocStore, error) {
+		return NewPgStore()
+	})
+}
+
+// PgStore is the AllocStore and LeaseStore backed by postgres
+type PgStore struct {
+	db *sql.DB
+}
+
+var (
+	_ AllocStore   = (*PgStore)(nil)
+	_ LeaseStore   = (*PgStore)(nil)
+	_ GaplessStore = (*PgStore)(nil)
+)
+
+// init postgres store by environment variables
+func NewPgStore() (*PgStore, error) {
+	dsn := getPgDsn(
+		os.Getenv(ENV_DB_USER),
+		os.Getenv(ENV_DB_PASS),
+		os.Getenv(ENV_DB_ADDR),
+		os.Getenv(ENV_DB_NAME),
+		os.Getenv(ENV_DB_SSLMODE))
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(100)
+	db.SetMaxIdleConns(100)
+	db.SetConnMaxLifetime(time.Minute * 3)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	err = db.PingContext(ctx)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect postgres: %v", err)
+	}
+
+	log.Println("postgres inited")
+	return &PgStore{db: db}, nil
+}
+
+func (s *PgStore) Close() error {
+	err := s.db.Close()
+	if err != nil {
+		log.Printf("can not close postgres: %v", err)
+		return err
+	}
+	log.Println("postgres closed")
+	return nil
+}
+
+func (s *PgStore) GetDB() *sql.DB {
+	return s.db
+}
+
+func getPgDsn(user, pass, addr, dbName, sslMode string) string {
+	// postgres://[user[:password]@][host][:port][/dbname][?param1=value1&...]
+	if sslMode == "" {
+		sslMode = "disable"
+	}
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(user, pass),
+		Host:     addr,
+		Path:     dbName,
+		RawQuery: url.Values{"sslmode": []string{sslMode}}.Encode(),
+	}
+	return u.String()
+}
+
+func (s *PgStore) QueryByKey(ctx context.Context, key string) (*Alloc, error) {
+	var alloc Alloc
+	query := fmt.Sprintf(
+		`select %s from %s where biz_key = $1`,
+		allocColumns,
+		TableName,
+	)
+	row := s.db.QueryRowContext(ctx, query, key)
+	err := scanAlloc(row, &alloc)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrKeyNotFound
+		}
+		log.Printf("dao pg query row err: %v\n", err)
+		return nil, pkg.ErrDb.Message(err.Error())
+	}
+
+	return &alloc, nil
+}
+
+func (s *PgStore) QueryAll(ctx context.Context) ([]*Alloc, error) {
+	query := fmt.Sprintf(
+		`select %s from %s`, allocColumns, TableName,
+	)
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		log.Printf("dao pg query all rows err: %v\n", err)
+		return nil, pkg.ErrDb.Message(err.Error())
+	}
+	defer rows.Close()
+
+	var allocs []*Alloc
+	for rows.Next() {
+		var alloc Alloc
+		err := scanAlloc(rows, &alloc)
+		if err != nil {
+			log.Printf("dao pg query all rows scan err: %v\n", err)
+			return nil, pkg.ErrDb.Message(err.Error())
+		}
+		allocs = append(allocs, &alloc)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, pkg.ErrDb.Message(err.Error())
+	}
+
+	return allocs, nil
+}
+
+func (s *PgStore) QueryAllKeys(ctx context.Context) ([]string, error) {
+	query := fmt.Sprintf("select biz_key from %s", TableName)
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		log.Printf("dao pg query all keys err: %v\n", err)
+		return nil, pkg.ErrDb.Message(err.Error())
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		err := rows.Scan(&key)
+		if err != nil {
+			log.Printf("dao pg query all keys scan err: %v\n", err)
+			return nil, pkg.ErrDb.Message(err.Error())
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, pkg.ErrDb.Message(err.Error())
+	}
+
+	return keys, nil
+}
+
+func (s *PgStore) QueryPage(ctx context.Context, afterKey string, limit int) ([]*Alloc, error) {
+	query := fmt.Sprintf(
+		`select %s from %s where biz_key > $1 order by biz_key limit $2`, allocColumns, TableName,
+	)
+
+	rows, err := s.db.QueryContext(ctx, query, afterKey, limit)
+	if err != nil {
+		log.Printf("dao pg query page err: %v\n", err)
+		return nil, pkg.ErrDb.Message(err.Error())
+	}
+	defer rows.Close()
+
+	var allocs []*Alloc
+	for rows.Next() {
+		var alloc Alloc
+		err := scanAlloc(rows, &alloc)
+		if err != nil {
+			log.Printf("dao pg query page scan err: %v\n", err)
+			return nil, pkg.ErrDb.Message(err.Error())
+		}
+		allocs = append(allocs, &alloc)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, pkg.ErrDb.Message(err.Error())
+	}
+
+	return allocs, nil
+}
+
+func (s *PgStore) CreateKey(ctx context.Context, alloc *Alloc) error {
+	if alloc == nil {
+		return ErrNilAlloc
+	}
+
+	created := createAlloc(alloc, time.Now().UnixMilli())
+	statement := fmt.Sprintf(
+		`insert into %s(biz_key, cur_id, step, min_step, max_step, min_id, max_id, exhaust_policy,
+		reset_period, time_zone, id_format, obfuscate_seed, gapless, disabled, created_at, updated_at)
+		values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)`,
+		TableName,
+	)
+	_, err := s.db.ExecContext(ctx, statement, created.Key, created.CurId, created.Step, created.MinStep, created.MaxStep,
+		created.MinId, created.MaxId, created.Exhaust, created.Period, created.TimeZone, created.Format, created.Seed, created.Gapless, created.Disabled,
+		created.CreatedAt, created.UpdatedAt)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == pgErrUniqueViolation {
+			return ErrKeyExists
+		}
+		log.Printf("dao pg create key err: %v\n", err)
+		return pkg.ErrDb.Message(err.Error())
+	}
+
+	return nil
+}
+
+func (s *PgStore) UpdateStep(ctx context.Context, key string, step, minStep, maxStep uint32) error {
+	statement := fmt.Sprintf(
+		"update %s set step = $1, min_step = $2, max_step = $3, updated_at = $4 where biz_key = $5",
+		TableName,
+	)
+	return s.updateKey(ctx, statement, step, minStep, maxStep, time.Now().UnixMilli(), key)
+}
+
+func (s *PgStore) UpdateFormat(ctx context.Context, key string, format string) error {
+	statement := fmt.Sprintf("update %s set id_format = $1, updated_at = $2 where biz_key = $3", TableName)
+	return s.updateKey(ctx, statement, format, time.Now().UnixMilli(), key)
+}
+
+func (s *PgStore) SetDisabled(ctx context.Context, key string, disabled bool) error {
+	statement := fmt.Sprintf("update %s set disabled = $1, updated_at = $2 where biz_key = $3", TableName)
+	return s.updateKey(ctx, statement, disabled, time.Now().UnixMilli(), key)
+}
+
+func (s *PgStore) AdvanceCurId(ctx context.Context, key string, curId uint64) (*Alloc, error) {
+	statement := fmt.Sprintf(
+		"update %s set cur_id = $1, updated_at = $2 where biz_key = $3 and cur_id < $1 and (max_id = 0 or $1 <= max_id + 1)",
+		TableName,
+	)
+	_, err := s.db.ExecContext(ctx, statement, curId, time.Now().UnixMilli(), key)
+	if err != nil {
+		log.Printf("dao pg advance cur id err: %v\n", err)
+		return nil, pkg.ErrDb.Message(err.Error())
+	}
+
+	// no row is updated if cur_id is already beyond curId or curId is beyond max_id
+	alloc, err := s.QueryByKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if !curIdInBounds(alloc, curId) {
+		return nil, ErrCurIdTooHigh
+	}
+
+	return alloc, nil
+}
+
+func (s *PgStore) DeleteKey(ctx context.Context, key string) error {
+	statement := fmt.Sprintf("delete from %s where biz_key = $1", TableName)
+	return s.updateKey(ctx, statement, key)
+}
+
+// updateKey executes the update statement of a key, ErrKeyNotFound is returned if no row is updated
+func (s *PgStore) updateKey(ctx context.Context, statement string, args ...interface{}) error {
+	res, err := s.db.ExecContext(ctx, statement, args...)
+	if err != nil {
+		log.Printf("dao pg update key err: %v\n", err)
+		return pkg.ErrDb.Message(err.Error())
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return pkg.ErrDb.Message(err.Error())
+	}
+
+	if affected == 0 {
+		return ErrKeyNotFound
+	}
+
+	return nil
+}
+
+func (s *PgStore) CreateUpdate(ctx context.Context, alloc *Alloc) error {
+	if alloc == nil {
+		return ErrNilAlloc
+	}
+
+	if alloc.Step == 0 {
+		alloc.Step = defaultStep
+	}
+
+	statement := `
+		insert into %s(biz_key, cur_id, step, min_step, max_step, min_id, max_id, exhaust_policy,
+		reset_period, time_zone, id_format, obfuscate_seed, gapless, created_at, updated_at)
+		values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $14)
+		on conflict (biz_key) do update set
+		cur_id = %s.cur_id + excluded.step,
+		step = excluded.step,
+		min_step = excluded.min_step,
+		max_step = excluded.max_step,
+		updated_at = excluded.updated_at
+	`
+
+	statement = fmt.Sprintf(statement, TableName, TableName)
+	now := time.Now().UnixMilli()
+	_, err := s.db.ExecContext(ctx, statement, alloc.Key, alloc.CurId, alloc.Step, alloc.MinStep, alloc.MaxStep,
+		alloc.MinId, alloc.MaxId, alloc.Exhaust, alloc.Period, alloc.TimeZone, alloc.Format, alloc.Seed, alloc.Gapless, now)
+	if err != nil {
+		log.Printf("dao pg create update err: %v\n", err)
+		return pkg.ErrDb.Message(err.Error())
+	}
+
+	return nil
+}
+
+// the row is inserted or advanced in one upsert, it is only locked and advanced in a transaction
+// if the range taken reaches max_id of key
+func (s *PgStore) TakeIdForKey(ctx context.Context, key string, newStep uint32) (*TakeIdResult, error) {
+	return s.takeId(ctx, key, newStep, false)
+}
+
+func (s *PgStore) LeaseRange(ctx context.Context, key string, size uint32) (*TakeIdResult, error) {
+	return s.takeId(ctx, key, size, true)
+}
+
+func (s *PgStore) takeId(ctx context.Context, key string, newStep uint32, keepStep bool) (*TakeIdResult, error) {
+	res, err := s.upsertTakeId(ctx, key, newStep, keepStep)
+	if errors.Is(err, sql.ErrNoRows) {
+		// key is disabled, gapless or reaches max_id, which is checked with the row locked
+		res, err = s.lockTakeId(ctx, key, newStep, keepStep)
+	}
+	if err != nil {
+		if pkgErr, ok := err.(*pkg.Err); ok {
+			return nil, pkgErr
+		}
+		log.Printf("dao pg take id err: %v\n", err)
+		return nil, pkg.ErrDb.Message(err.Error())
+	}
+
+	return res, nil
+}
+
+// upsertTakeId inserts key or advances cur_id of key by newStep, newStep is saved as the step of key unless keepStep is set.
+// Nothing is updated and sql.ErrNoRows is returned if key is disabled, gapless or the range would pass max_id
+func (s *PgStore) upsertTakeId(ctx context.Context, key string, newStep uint32, keepStep bool) (*TakeIdResult, error) {
+	initStep := newStep
+	if initStep == 0 || keepStep {
+		initStep = defaultStep
+	}
+	initInc := newStep
+	if initInc == 0 {
+		initInc = defaultStep
+	}
+
+	// inc is the number of ids taken and begin is where the range starts, the same as takeRange does
+	const (
+		inc   = "coalesce(nullif($5::bigint, 0), nullif(%[1]s.step, 0), $6::bigint)"
+		begin = "greatest(%[1]s.cur_id, %[1]s.min_id, $7::bigint)"
+	)
+	statement := fmt.Sprintf(`insert into %[1]s(biz_key, cur_id, step, created_at, updated_at)
+		values ($1, $2, $3, $4, $4)
+		on conflict (biz_key) do update set
+		cur_id = `+begin+` + `+inc+`,
+		step = case when $8::boolean then %[1]s.step else `+inc+` end,
+		updated_at = $4
+		where not %[1]s.disabled and not %[1]s.gapless
+		and (%[1]s.max_id = 0 or `+begin+` + `+inc+` <= %[1]s.max_id + 1)
+		returning cur_id, step, min_step, max_step, obfuscate_seed`, TableName)
+
+	var (
+		end              uint64
+		step             uint32
+		minStep, maxStep uint32
+		seed             uint64
+	)
+	err := s.db.QueryRowContext(ctx, statement,
+		key, defaultCurId+uint64(initInc), initStep, time.Now().UnixMilli(),
+		newStep, defaultStep, defaultCurId, keepStep,
+	).Scan(&end, &step, &minStep, &maxStep, &seed)
+	if err != nil {
+		return nil, err
+	}
+
+	if newStep == 0 {
+		// the current step of key is taken and kept
+		newStep = step
+	}
+	return &TakeIdResult{
+		Begin:   end - uint64(newStep),
+		End:     end,
+		Step:    step,
+		MinStep: minStep,
+		MaxStep: maxStep,
+		Seed:    seed,
+	}, nil
+}
+
+// lockTakeId advances cur_id of key by newStep with the row locked, the range is cut short at max_id
+// or cycled back to min_id, newStep is saved as the step of key unless keepStep is set
+func (s *PgStore) lockTakeId(ctx context.Context, key string, newStep uint32, keepStep bool) (*TakeIdResult, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var rollback = true
+	defer func() {
+		if rollback {
+			tx.Rollback()
+		}
+	}()
+
+	var (
+		curId            uint64
+		step             uint32
+		minStep, maxStep uint32
+		minId, maxId     uint64
+		exhaust          ExhaustPolicy
+		seed             uint64
+		gapless          bool
+		disabled         bool
+	)
+	err = tx.QueryRowContext(ctx,
+		fmt.Sprintf(`select cur_id, step, min_step, max_step, min_id, max_id, exhaust_policy, obfuscate_seed, gapless, disabled
+		from %s where biz_key = $1 for update`, TableName),
+		key,
+	).Scan(&curId, &step, &minStep, &maxStep, &minId, &maxId, &exhaust, &seed, &gapless, &disabled)
+	if err != nil {
+		return nil, err
+	}
+
+	if disabled {
+		return nil, ErrKeyDisabled
+	}
+	if gapless {
+		return nil, ErrKeyGapless
+	}
+
+	if step == 0 {
+		step = defaultStep
+	}
+	if newStep == 0 {
+		// keep the current step of key
+		newStep = step
+	}
+	if !keepStep {
+		step = newStep
+	}
+
+	begin, end, err := takeRange(curId, newStep, minId, maxId, exhaust)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx,
+		fmt.Sprintf("update %s set cur_id = $1, step = $2, updated_at = $3 where biz_key = $4", TableName),
+		end, step, time.Now().UnixMilli(), key,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	rollback = false
+
+	return &TakeIdResult{
+		Begin:   begin,
+		End:     end,
+		Step:    step,
+		MinStep: minStep,
+		MaxStep: maxStep,
+		Seed:    seed,
+	}, nil
+}
+
+func (s *PgStore) ClaimWorker(ctx context.Context, owner string, maxWorkerId uint64, ttl time.Duration) (*WorkerLease, error) {
+	var err error
+	for i := 0; i < claimRetries; i++ {
+		var lease *WorkerLease
+		lease, err = s.claimWorker(ctx, owner, maxWorkerId, ttl)
+		if err == nil {
+			return lease, nil
+		}
+
+		var pqErr *pq.Error
+		if !errors.As(err, &pqErr) || pqErr.Code != pgErrUniqueViolation {
+			break
+		}
+		// another node inserted the same worker id, try again
+	}
+
+	if pkgErr, ok := err.(*pkg.Err); ok {
+		return nil, pkgErr
+	}
+	log.Printf("dao pg claim worker err: %v\n", err)
+	return nil, pkg.ErrDb.Message(err.Error())
+}
+
+func (s *PgStore) claimWorker(ctx context.Context, owner string, maxWorkerId uint64, ttl time.Duration) (*WorkerLease, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var rollback = true
+	defer func() {
+		if rollback {
+			tx.Rollback()
+		}
+	}()
+
+	rows, err := tx.QueryContext(ctx,
+		fmt.Sprintf("select %s from %s where worker_id <= $1 order by worker_id for update", leaseColumns, LeaseTableName),
+		maxWorkerId,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	leases := make(map[uint64]*WorkerLease)
+	for rows.Next() {
+		var lease WorkerLease
+		err = rows.Scan(&lease.Id,
+			&lease.WorkerId,
+			&lease.Owner,
+			&lease.ExpireAt,
+			&lease.LastTs,
+			&lease.CreatedAt,
+			&lease.UpdatedAt)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		leases[lease.WorkerId] = &lease
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	picked := pickFreeWorker(leases, maxWorkerId, now)
+	if picked == nil {
+		return nil, ErrNoFreeWorker
+	}
+
+	picked.Owner = owner
+	picked.ExpireAt = now + ttl.Milliseconds()
+	picked.UpdatedAt = now
+
+	statement := `
+		insert into %s(worker_id, owner, expire_at, created_at, updated_at)
+		values ($1, $2, $3, $4, $5)
+		on conflict (worker_id) do update set
+		owner = excluded.owner,
+		expire_at = excluded.expire_at,
+		updated_at = excluded.updated_at
+	`
+	statement = fmt.Sprintf(statement, LeaseTableName)
+	_, err = tx.ExecContext(ctx, statement,
+		picked.WorkerId, picked.Owner, picked.ExpireAt, picked.CreatedAt, picked.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	rollback = false
+
+	return picked, nil
+}
+
+func (s *PgStore) RenewWorker(ctx context.Context, workerId uint64, owner string, ttl time.Duration, lastTs int64) (int64, error) {
+	now := time.Now().UnixMilli()
+	expireAt := now + ttl.Milliseconds()
+	statement := fmt.Sprintf(
+		`update %s set expire_at = $1, last_ts = greatest(last_ts, $2), updated_at = $3
+		where worker_id = $4 and owner = $5 and expire_at >= $3`,
+		LeaseTableName,
+	)
+	res, err := s.db.ExecContext(ctx, statement, expireAt, lastTs, now, workerId, owner)
+	if err != nil {
+		log.Printf("dao pg renew worker err: %v\n", err)
+		return 0, pkg.ErrDb.Message(err.Error())
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, pkg.ErrDb.Message(err.Error())
+	}
+
+	if affected == 0 {
+		// the lease is expired or taken by others
+		return 0, ErrLeaseLost
+	}
+
+	return expireAt, nil
+}
+
+func (s *PgStore) ReleaseWorker(ctx context.Context, workerId uint64, owner string, lastTs int64) error {
+	now := time.Now().UnixMilli()
+	statement := fmt.Sprintf(
+		"update %s set expire_at = 0, last_ts = greatest(last_ts, $1), updated_at = $2 where worker_id = $3 and owner = $4",
+		LeaseTableName,
+	)
+	_, err := s.db.ExecContext(ctx, statement, lastTs, now, workerId, owner)
+	if err != nil {
+		log.Printf("dao pg release worker err: %v\n", err)
+		return pkg.ErrDb.Message(err.Error())
+	}
+
+	return nil
+}
+
+func (s *PgStore) QueryNowMs(ctx context.Context) (int64, error) {
+	var now int64
+	err := s.db.QueryRowContext(ctx, "select (extract(epoch from clock_timestamp()) * 1000)::bigint").Scan(&now)
+	if err != nil {
+		log.Printf("dao pg query now err: %v\n", err)
+		return 0, pkg.ErrDb.Message(err.Error())
+	}
+
+	return now, nil
+}
+
+func (s *PgStore) ReserveNum(ctx context.Context, key string, token string, ttl time.Duration) (*Reservation, error) {
+	r, err := s.reserveNum(ctx, key, token, ttl)
+	if err != nil {
+		if pkgErr, ok := err.(*pkg.Err); ok {
+			return nil, pkgErr
+		}
+		log.Printf("dao pg reserve num err: %v\n", err)
+		return nil, pkg.ErrDb.Message(err.Error())
+	}
+
+	return r, nil
+}
+
+func (s *PgStore) reserveNum(ctx context.Context, key string, token string, ttl time.Duration) (*Reservation, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var rollback = true
+	defer func() {
+		if rollback {
+			tx.Rollback()
+		}
+	}()
+
+	// reservations of key are serialized by the lock of its alloc row
+	var alloc Alloc
+	row := tx.QueryRowContext(ctx,
+		fmt.Sprintf("select %s from %s where biz_key = $1 for update", allocColumns, TableName),
+		key,
+	)
+	if err = scanAlloc(row, &alloc); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrKeyNotFound
+		}
+		return nil, err
+	}
+
+	if err = checkGapless(&alloc); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	r := &Reservation{Key: key, Token: token, ExpireAt: now + ttl.Milliseconds(), CreatedAt: now, UpdatedAt: now}
+	err = tx.QueryRowContext(ctx,
+		fmt.Sprintf("select num from %s where biz_key = $1 and expire_at < $2 order by num limit 1 for update", GaplessTableName),
+		key, now,
+	).Scan(&r.Num)
+	if err == nil {
+		// numbers given back are reserved before any new one
+		_, err = tx.ExecContext(ctx,
+			fmt.Sprintf("update %s set token = $1, expire_at = $2, updated_at = $3 where biz_key = $4 and num = $5", GaplessTableName),
+			r.Token, r.ExpireAt, now, key, r.Num,
+		)
+	} else if errors.Is(err, sql.ErrNoRows) {
+		r.Num, err = nextNum(&alloc)
+		if err != nil {
+			return nil, err
+		}
+		_, err = tx.ExecContext(ctx,
+			fmt.Sprintf("update %s set cur_id = $1, updated_at = $2 where biz_key = $3", TableName),
+			alloc.CurId, now, key,
+		)
+		if err == nil {
+			_, err = tx.ExecContext(ctx,
+				fmt.Sprintf(`insert into %s(biz_key, num, token, expire_at, created_at, updated_at)
+				values ($1, $2, $3, $4, $5, $5)`, GaplessTableName),
+				key, r.Num, r.Token, r.ExpireAt, now,
+			)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	rollback = false
+
+	return r, nil
+}
+
+func (s *PgStore) ConfirmNum(ctx context.Context, key string, num uint64, token string) error {
+	statement := fmt.Sprintf(
+		"delete from %s where biz_key = $1 and num = $2 and token = $3 and expire_at >= $4",
+		GaplessTableName,
+	)
+	return s.updateReservation(ctx, statement, key, num, token, time.Now().UnixMilli())
+}
+
+func (s *PgStore) ReleaseNum(ctx context.Context, key string, num uint64, token string) error {
+	statement := fmt.Sprintf(
+		`update %s set token = '', expire_at = 0, updated_at = $1
+		where biz_key = $2 and num = $3 and token = $4 and expire_at >= $1`,
+		GaplessTableName,
+	)
+	return s.updateReservation(ctx, statement, time.Now().UnixMilli(), key, num, token)
+}
+
+// updateReservation runs statement on a reservation, ErrReservationNotFound is returned if no row is affected
+func (s *PgStore) updateReservation(ctx context.Context, statement string, args ...interface{}) error {
+	res, err := s.db.ExecContext(ctx, statement, args...)
+	if err != nil {
+		log.Printf("dao pg update reservation err: %v\n", err)
+		return pkg.ErrDb.Message(err.Error())
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return pkg.ErrDb.Message(err.Error())
+	}
+
+	if affected == 0 {
+		return ErrReservationNotFound
+	}
+
+	return nil
+}
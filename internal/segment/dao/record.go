@@ -0,0 +1,205 @@
+package dao
+
+import "sort"
+
+// record level logic shared by stores which keep records in go, like bolt and memory store
+
+// takeAlloc advances alloc of key by newStep, alloc is nil if key does not exist,
+// newStep is saved as the step of key unless it is zero or keepStep is set,
+// the updated alloc and the range taken are returned
+func takeAlloc(alloc *Alloc, key string, newStep uint32, keepStep bool, now int64) (*Alloc, *TakeIdResult, error) {
+	if alloc != nil && alloc.Disabled {
+		return nil, nil, ErrKeyDisabled
+	}
+	if alloc != nil && alloc.Gapless {
+		return nil, nil, ErrKeyGapless
+	}
+
+	step := defaultStep
+	if alloc != nil && alloc.Step != 0 {
+		step = alloc.Step
+	}
+	if newStep == 0 {
+		newStep = step
+	}
+	if !keepStep {
+		step = newStep
+	}
+
+	if alloc == nil {
+		alloc = &Alloc{
+			Key:       key,
+			CurId:     defaultCurId,
+			CreatedAt: now,
+		}
+	}
+
+	begin, end, err := takeRange(alloc.CurId, newStep, alloc.MinId, alloc.MaxId, alloc.Exhaust)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	alloc.CurId = end
+	alloc.Step = step
+	alloc.UpdatedAt = now
+
+	return alloc, &TakeIdResult{
+		Begin:   begin,
+		End:     end,
+		Step:    alloc.Step,
+		MinStep: alloc.MinStep,
+		MaxStep: alloc.MaxStep,
+		Seed:    alloc.Seed,
+	}, nil
+}
+
+// advanceAlloc raises cur_id of alloc to curId, curId may be max_id+1 at most if alloc has max_id
+func advanceAlloc(alloc *Alloc, curId uint64, now int64) error {
+	if !curIdInBounds(alloc, curId) {
+		return ErrCurIdTooHigh
+	}
+	if curId > alloc.CurId {
+		alloc.CurId = curId
+		alloc.UpdatedAt = now
+	}
+	return nil
+}
+
+func curIdInBounds(alloc *Alloc, curId uint64) bool {
+	return alloc.MaxId == 0 || curId <= alloc.MaxId+1
+}
+
+// mergeAlloc creates alloc or updates old with alloc, alloc.Step should not be zero
+func mergeAlloc(old, alloc *Alloc, now int64) *Alloc {
+	if old == nil {
+		return &Alloc{
+			Key:       alloc.Key,
+			CurId:     alloc.CurId,
+			Step:      alloc.Step,
+			MinStep:   alloc.MinStep,
+			MaxStep:   alloc.MaxStep,
+			MinId:     alloc.MinId,
+			MaxId:     alloc.MaxId,
+			Exhaust:   alloc.Exhaust,
+			Period:    alloc.Period,
+			TimeZone:  alloc.TimeZone,
+			Format:    alloc.Format,
+			Seed:      alloc.Seed,
+			Gapless:   alloc.Gapless,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+	}
+
+	old.CurId += uint64(alloc.Step)
+	old.Step = alloc.Step
+	old.MinStep = alloc.MinStep
+	old.MaxStep = alloc.MaxStep
+	old.UpdatedAt = now
+	return old
+}
+
+// createAlloc returns the record of a new key, cur_id starts from min_id or 1 if it is not given
+func createAlloc(alloc *Alloc, now int64) *Alloc {
+	created := *alloc
+	created.Id = 0
+	if created.CurId == 0 {
+		created.CurId = max(created.MinId, defaultCurId)
+	}
+	if created.Step == 0 {
+		created.Step = defaultStep
+	}
+	created.CreatedAt = now
+	created.UpdatedAt = now
+	return &created
+}
+
+// checkGapless makes sure numbers can be reserved from alloc
+func checkGapless(alloc *Alloc) error {
+	if alloc.Disabled {
+		return ErrKeyDisabled
+	}
+	if !alloc.Gapless {
+		return ErrKeyNotGapless
+	}
+	return nil
+}
+
+// nextNum takes a new number from alloc of gapless key, numbers never cycle so that none is reused
+func nextNum(alloc *Alloc) (uint64, error) {
+	begin, _, err := takeRange(alloc.CurId, 1, alloc.MinId, alloc.MaxId, ExhaustError)
+	if err != nil {
+		return 0, err
+	}
+	alloc.CurId = begin + 1
+	return begin, nil
+}
+
+// reserveAlloc reserves reusable, or a new number of alloc if reusable is nil, until expireAt
+func reserveAlloc(alloc *Alloc, reusable *Reservation, token string, expireAt, now int64) (*Reservation, error) {
+	if err := checkGapless(alloc); err != nil {
+		return nil, err
+	}
+
+	if reusable != nil {
+		reusable.Token = token
+		reusable.ExpireAt = expireAt
+		reusable.UpdatedAt = now
+		return reusable, nil
+	}
+
+	num, err := nextNum(alloc)
+	if err != nil {
+		return nil, err
+	}
+	alloc.UpdatedAt = now
+
+	return &Reservation{
+		Key:       alloc.Key,
+		Num:       num,
+		Token:     token,
+		ExpireAt:  expireAt,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// reserved reports whether r is reserved with token and not expired at now
+func reserved(r *Reservation, token string, now int64) bool {
+	return r != nil && r.Token == token && r.ExpireAt >= now
+}
+
+// pageAllocs returns at most limit allocs whose keys are greater than afterKey, allocs should be sorted by key
+func pageAllocs(allocs []*Alloc, afterKey string, limit int) []*Alloc {
+	i := sort.Search(len(allocs), func(i int) bool { return allocs[i].Key > afterKey })
+	allocs = allocs[i:]
+	if len(allocs) > limit {
+		allocs = allocs[:limit]
+	}
+	return allocs
+}
+
+// renewLease extends lease held by owner to expireAt
+func renewLease(lease *WorkerLease, owner string, expireAt, lastTs, now int64) error {
+	if lease == nil || lease.Owner != owner || lease.ExpireAt < now {
+		// the lease is expired or taken by others
+		return ErrLeaseLost
+	}
+
+	lease.ExpireAt = expireAt
+	lease.LastTs = max(lease.LastTs, lastTs)
+	lease.UpdatedAt = now
+	return nil
+}
+
+// releaseLease gives up lease held by owner, false is returned if owner does not hold the lease
+func releaseLease(lease *WorkerLease, owner string, lastTs, now int64) bool {
+	if lease == nil || lease.Owner != owner {
+		return false
+	}
+
+	lease.ExpireAt = 0
+	lease.LastTs = max(lease.LastTs, lastTs)
+	lease.UpdatedAt = now
+	return true
+}
@@ -0,0 +1,152 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ryanreadbooks/folium/internal/pkg"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	StoreMysql = "mysql"
+
+	DefaultStore = StoreMysql
+)
+
+var (
+	ErrNilAlloc     = pkg.ErrInvalidArgs.Message("alloc arg is nil")
+	ErrKeyNotFound  = pkg.NewErr(int(codes.NotFound), "key is not found")
+	ErrKeyExists    = pkg.NewErr(int(codes.AlreadyExists), "key already exists")
+	ErrKeyDisabled  = pkg.NewErr(int(codes.FailedPrecondition), "key is disabled")
+	ErrKeyExhausted = pkg.NewErr(int(codes.OutOfRange), "key is exhausted")
+	ErrKeyGapless   = pkg.NewErr(int(codes.FailedPrecondition), "key is gapless, its ids can only be reserved")
+	ErrCurIdTooHigh = pkg.NewErr(int(codes.OutOfRange), "cur id is beyond max id of key")
+)
+
+type TakeIdResult struct {
+	Begin   uint64
+	End     uint64
+	Step    uint32
+	MinStep uint32 // step bounds of key, zero means not set
+	MaxStep uint32
+	Seed    uint64 // obfuscate seed of key, zero means ids of key are not obfuscated
+}
+
+// AllocStore persists the alloc records of keys
+type AllocStore interface {
+	// TakeIdForKey advances cur_id of key by newStep and returns the range [Begin, End) taken,
+	// zero newStep keeps the current step of key, or the default step if the key does not exist.
+	// The range is cut short at max_id of key, and ErrKeyExhausted is returned once max_id is passed
+	// unless the key cycles back to min_id.
+	// The key is created if it does not exist, ErrKeyDisabled is returned if the key is disabled
+	// and ErrKeyGapless is returned if the key is gapless
+	TakeIdForKey(ctx context.Context, key string, newStep uint32) (*TakeIdResult, error)
+
+	// LeaseRange advances cur_id of key by size like TakeIdForKey, but the step of key is left unchanged
+	LeaseRange(ctx context.Context, key string, size uint32) (*TakeIdResult, error)
+
+	// QueryByKey returns ErrKeyNotFound if key does not exist
+	QueryByKey(ctx context.Context, key string) (*Alloc, error)
+
+	// QueryAll retrieves all the alloc records
+	QueryAll(ctx context.Context) ([]*Alloc, error)
+
+	QueryAllKeys(ctx context.Context) ([]string, error)
+
+	// QueryPage returns at most limit alloc records ordered by key whose keys are greater than afterKey
+	QueryPage(ctx context.Context, afterKey string, limit int) ([]*Alloc, error)
+
+	// create or update the alloc given the specific key
+	CreateUpdate(ctx context.Context, alloc *Alloc) error
+
+	// CreateKey creates the alloc of a new key, ErrKeyExists is returned if the key exists
+	CreateKey(ctx context.Context, alloc *Alloc) error
+
+	// UpdateStep changes step and step bounds of key, ErrKeyNotFound is returned if key does not exist
+	UpdateStep(ctx context.Context, key string, step, minStep, maxStep uint32) error
+
+	// UpdateFormat changes the id format template of key, ErrKeyNotFound is returned if key does not exist
+	UpdateFormat(ctx context.Context, key string, format string) error
+
+	// SetDisabled disables or re-enables key, ErrKeyNotFound is returned if key does not exist
+	SetDisabled(ctx context.Context, key string, disabled bool) error
+
+	// AdvanceCurId raises cur_id of key to curId, cur_id is never lowered,
+	// the alloc after advancing is returned and ErrKeyNotFound is returned if key does not exist,
+	// ErrCurIdTooHigh is returned if key has max_id and curId is greater than max_id+1
+	AdvanceCurId(ctx context.Context, key string, curId uint64) (*Alloc, error)
+
+	// DeleteKey deletes the alloc of key, ErrKeyNotFound is returned if key does not exist
+	DeleteKey(ctx context.Context, key string) error
+
+	Close() error
+}
+
+// LeaseStore persists the worker leases of snowflake,
+// an AllocStore can optionally implement it
+type LeaseStore interface {
+	// ClaimWorker claims a worker id in [0, maxWorkerId] which is not leased or whose lease is expired
+	ClaimWorker(ctx context.Context, owner string, maxWorkerId uint64, ttl time.Duration) (*WorkerLease, error)
+
+	// RenewWorker extends the lease of workerId held by owner and persists the last used timestamp lastTs,
+	// the new expire unix ms is returned
+	RenewWorker(ctx context.Context, workerId uint64, owner string, ttl time.Duration, lastTs int64) (int64, error)
+
+	// ReleaseWorker gives up the lease of workerId held by owner and persists the last used timestamp lastTs
+	ReleaseWorker(ctx context.Context, workerId uint64, owner string, lastTs int64) error
+
+	// QueryNowMs returns the current unix ms of store
+	QueryNowMs(ctx context.Context) (int64, error)
+}
+
+// StoreOpener opens a store, configurations are read by the opener itself
+type StoreOpener func() (AllocStore, error)
+
+var (
+	storesMu sync.Mutex
+	stores   = make(map[string]StoreOpener)
+)
+
+// RegisterStore makes a store backend available by name
+func RegisterStore(name string, opener StoreOpener) {
+	storesMu.Lock()
+	defer storesMu.Unlock()
+
+	if _, ok := stores[name]; ok {
+		panic(fmt.Sprintf("store %s is registered twice", name))
+	}
+	stores[name] = opener
+}
+
+// OpenStore opens the store backend registered as name
+func OpenStore(name string) (AllocStore, error) {
+	if name == "" {
+		name = DefaultStore
+	}
+
+	storesMu.Lock()
+	opener, ok := stores[name]
+	storesMu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("store %s is not supported, available stores: %v", name, Stores())
+	}
+
+	return opener()
+}
+
+// Stores returns the names of registered stores
+func Stores() []string {
+	storesMu.Lock()
+	defer storesMu.Unlock()
+
+	names := make([]string, 0, len(stores))
+	for name := range stores {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
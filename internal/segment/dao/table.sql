@@ -1,10 +1,46 @@
+-- schema of a new deployment, existing tables are upgraded by upgrade.sql
 CREATE TABLE IF NOT EXISTS alloc_table (
   id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'primary key',
   biz_key VARCHAR(128) NOT NULL DEFAULT '' COMMENT 'biz key identifier',
   cur_id BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT 'max id',
   step INT UNSIGNED NOT NULL DEFAULT 0 COMMENT 'step',
+  min_step INT UNSIGNED NOT NULL DEFAULT 0 COMMENT 'min adaptive step, 0 means not set',
+  max_step INT UNSIGNED NOT NULL DEFAULT 0 COMMENT 'max adaptive step, 0 means not set',
+  min_id BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT 'ids start from and cycle back to min id, 0 means 1',
+  max_id BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT 'the largest id, 0 means no limit',
+  exhaust_policy TINYINT UNSIGNED NOT NULL DEFAULT 0 COMMENT 'what to do when max id is reached, 0 error, 1 cycle',
+  reset_period TINYINT UNSIGNED NOT NULL DEFAULT 0 COMMENT 'how often the sequence starts over, 0 never, 1 daily, 2 monthly',
+  time_zone VARCHAR(64) NOT NULL DEFAULT '' COMMENT 'time zone where periods roll over, empty means UTC',
+  id_format VARCHAR(128) NOT NULL DEFAULT '' COMMENT 'template which ids are rendered with, empty means not set',
+  obfuscate_seed BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT 'secret which ids are obfuscated with, 0 means not obfuscated',
+  gapless TINYINT(1) NOT NULL DEFAULT 0 COMMENT 'ids of gapless key are reserved one by one and never skipped',
+  disabled TINYINT(1) NOT NULL DEFAULT 0 COMMENT 'no id can be taken from disabled key',
   created_at BIGINT NOT NULL DEFAULT 0 COMMENT 'created unix ms',
   updated_at BIGINT NOT NULL DEFAULT 0 COMMENT 'updated unix ms',
   PRIMARY KEY (id),
   UNIQUE KEY uk_key(biz_key)
 )ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='segment allocation table';
+
+CREATE TABLE IF NOT EXISTS worker_lease (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'primary key',
+  worker_id BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT 'snowflake worker id',
+  owner VARCHAR(128) NOT NULL DEFAULT '' COMMENT 'node which holds the lease',
+  expire_at BIGINT NOT NULL DEFAULT 0 COMMENT 'lease expired unix ms',
+  last_ts BIGINT NOT NULL DEFAULT 0 COMMENT 'last used timestamp unix ms',
+  created_at BIGINT NOT NULL DEFAULT 0 COMMENT 'created unix ms',
+  updated_at BIGINT NOT NULL DEFAULT 0 COMMENT 'updated unix ms',
+  PRIMARY KEY (id),
+  UNIQUE KEY uk_worker_id(worker_id)
+)ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='snowflake worker id lease table';
+
+CREATE TABLE IF NOT EXISTS gapless_reservation (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'primary key',
+  biz_key VARCHAR(128) NOT NULL DEFAULT '' COMMENT 'gapless biz key',
+  num BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT 'reserved number',
+  token VARCHAR(64) NOT NULL DEFAULT '' COMMENT 'token of the reservation holder',
+  expire_at BIGINT NOT NULL DEFAULT 0 COMMENT 'reservation expired unix ms, 0 means released',
+  created_at BIGINT NOT NULL DEFAULT 0 COMMENT 'created unix ms',
+  updated_at BIGINT NOT NULL DEFAULT 0 COMMENT 'updated unix ms',
+  PRIMARY KEY (id),
+  UNIQUE KEY uk_key_num(biz_key, num)
+)ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='gapless number reservation table, confirmed numbers are deleted';
@@ -0,0 +1,80 @@
+-- schema of a new deployment, existing tables are upgraded by upgrade_pg.sql
+CREATE TABLE IF NOT EXISTS alloc_table (
+  id BIGSERIAL NOT NULL,
+  biz_key VARCHAR(128) NOT NULL DEFAULT '',
+  cur_id BIGINT NOT NULL DEFAULT 0,
+  step INTEGER NOT NULL DEFAULT 0,
+  min_step INTEGER NOT NULL DEFAULT 0,
+  max_step INTEGER NOT NULL DEFAULT 0,
+  min_id BIGINT NOT NULL DEFAULT 0,
+  max_id BIGINT NOT NULL DEFAULT 0,
+  exhaust_policy SMALLINT NOT NULL DEFAULT 0,
+  reset_period SMALLINT NOT NULL DEFAULT 0,
+  time_zone VARCHAR(64) NOT NULL DEFAULT '',
+  id_format VARCHAR(128) NOT NULL DEFAULT '',
+  obfuscate_seed BIGINT NOT NULL DEFAULT 0,
+  gapless BOOLEAN NOT NULL DEFAULT FALSE,
+  disabled BOOLEAN NOT NULL DEFAULT FALSE,
+  created_at BIGINT NOT NULL DEFAULT 0,
+  updated_at BIGINT NOT NULL DEFAULT 0,
+  PRIMARY KEY (id),
+  CONSTRAINT uk_key UNIQUE (biz_key)
+);
+COMMENT ON TABLE alloc_table IS 'segment allocation table';
+COMMENT ON COLUMN alloc_table.id IS 'primary key';
+COMMENT ON COLUMN alloc_table.biz_key IS 'biz key identifier';
+COMMENT ON COLUMN alloc_table.cur_id IS 'max id';
+COMMENT ON COLUMN alloc_table.step IS 'step';
+COMMENT ON COLUMN alloc_table.min_step IS 'min adaptive step, 0 means not set';
+COMMENT ON COLUMN alloc_table.max_step IS 'max adaptive step, 0 means not set';
+COMMENT ON COLUMN alloc_table.min_id IS 'ids start from and cycle back to min id, 0 means 1';
+COMMENT ON COLUMN alloc_table.max_id IS 'the largest id, 0 means no limit';
+COMMENT ON COLUMN alloc_table.exhaust_policy IS 'what to do when max id is reached, 0 error, 1 cycle';
+COMMENT ON COLUMN alloc_table.reset_period IS 'how often the sequence starts over, 0 never, 1 daily, 2 monthly';
+COMMENT ON COLUMN alloc_table.time_zone IS 'time zone where periods roll over, empty means UTC';
+COMMENT ON COLUMN alloc_table.id_format IS 'template which ids are rendered with, empty means not set';
+COMMENT ON COLUMN alloc_table.obfuscate_seed IS 'secret which ids are obfuscated with, 0 means not obfuscated';
+COMMENT ON COLUMN alloc_table.gapless IS 'ids of gapless key are reserved one by one and never skipped';
+COMMENT ON COLUMN alloc_table.disabled IS 'no id can be taken from disabled key';
+COMMENT ON COLUMN alloc_table.created_at IS 'created unix ms';
+COMMENT ON COLUMN alloc_table.updated_at IS 'updated unix ms';
+
+CREATE TABLE IF NOT EXISTS worker_lease (
+  id BIGSERIAL NOT NULL,
+  worker_id BIGINT NOT NULL DEFAULT 0,
+  owner VARCHAR(128) NOT NULL DEFAULT '',
+  expire_at BIGINT NOT NULL DEFAULT 0,
+  last_ts BIGINT NOT NULL DEFAULT 0,
+  created_at BIGINT NOT NULL DEFAULT 0,
+  updated_at BIGINT NOT NULL DEFAULT 0,
+  PRIMARY KEY (id),
+  CONSTRAINT uk_worker_id UNIQUE (worker_id)
+);
+COMMENT ON TABLE worker_lease IS 'snowflake worker id lease table';
+COMMENT ON COLUMN worker_lease.id IS 'primary key';
+COMMENT ON COLUMN worker_lease.worker_id IS 'snowflake worker id';
+COMMENT ON COLUMN worker_lease.owner IS 'node which holds the lease';
+COMMENT ON COLUMN worker_lease.expire_at IS 'lease expired unix ms';
+COMMENT ON COLUMN worker_lease.last_ts IS 'last used timestamp unix ms';
+COMMENT ON COLUMN worker_lease.created_at IS 'created unix ms';
+COMMENT ON COLUMN worker_lease.updated_at IS 'updated unix ms';
+
+CREATE TABLE IF NOT EXISTS gapless_reservation (
+  id BIGSERIAL NOT NULL,
+  biz_key VARCHAR(128) NOT NULL DEFAULT '',
+  num BIGINT NOT NULL DEFAULT 0,
+  token VARCHAR(64) NOT NULL DEFAULT '',
+  expire_at BIGINT NOT NULL DEFAULT 0,
+  created_at BIGINT NOT NULL DEFAULT 0,
+  updated_at BIGINT NOT NULL DEFAULT 0,
+  PRIMARY KEY (id),
+  CONSTRAINT uk_key_num UNIQUE (biz_key, num)
+);
+COMMENT ON TABLE gapless_reservation IS 'gapless number reservation table, confirmed numbers are deleted';
+COMMENT ON COLUMN gapless_reservation.id IS 'primary key';
+COMMENT ON COLUMN gapless_reservation.biz_key IS 'gapless biz key';
+COMMENT ON COLUMN gapless_reservation.num IS 'reserved number';
+COMMENT ON COLUMN gapless_reservation.token IS 'token of the reservation holder';
+COMMENT ON COLUMN gapless_reservation.expire_at IS 'reservation expired unix ms, 0 means released';
+COMMENT ON COLUMN gapless_reservation.created_at IS 'created unix ms';
+COMMENT ON COLUMN gapless_reservation.updated_at IS 'updated unix ms';
@@ -0,0 +1,21 @@
+-- upgrade alloc_table and worker_lease created by an older table.sql to the current schema,
+-- run table.sql first to create the tables which do not exist yet, then run this file.
+-- MySQL can not add a column only if it does not exist, so every column is added in its own statement
+-- and the statements of columns which already exist fail with "Duplicate column name", run it with
+--   mysql --force -h <host> -u <user> -p <db> < upgrade.sql
+-- so that those failures are skipped. Upgrade the schema before rolling out the new version,
+-- the new columns have defaults which keep the behavior of existing keys.
+
+ALTER TABLE alloc_table ADD COLUMN min_step INT UNSIGNED NOT NULL DEFAULT 0 COMMENT 'min adaptive step, 0 means not set' AFTER step;
+ALTER TABLE alloc_table ADD COLUMN max_step INT UNSIGNED NOT NULL DEFAULT 0 COMMENT 'max adaptive step, 0 means not set' AFTER min_step;
+ALTER TABLE alloc_table ADD COLUMN min_id BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT 'ids start from and cycle back to min id, 0 means 1' AFTER max_step;
+ALTER TABLE alloc_table ADD COLUMN max_id BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT 'the largest id, 0 means no limit' AFTER min_id;
+ALTER TABLE alloc_table ADD COLUMN exhaust_policy TINYINT UNSIGNED NOT NULL DEFAULT 0 COMMENT 'what to do when max id is reached, 0 error, 1 cycle' AFTER max_id;
+ALTER TABLE alloc_table ADD COLUMN reset_period TINYINT UNSIGNED NOT NULL DEFAULT 0 COMMENT 'how often the sequence starts over, 0 never, 1 daily, 2 monthly' AFTER exhaust_policy;
+ALTER TABLE alloc_table ADD COLUMN time_zone VARCHAR(64) NOT NULL DEFAULT '' COMMENT 'time zone where periods roll over, empty means UTC' AFTER reset_period;
+ALTER TABLE alloc_table ADD COLUMN id_format VARCHAR(128) NOT NULL DEFAULT '' COMMENT 'template which ids are rendered with, empty means not set' AFTER time_zone;
+ALTER TABLE alloc_table ADD COLUMN obfuscate_seed BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT 'secret which ids are obfuscated with, 0 means not obfuscated' AFTER id_format;
+ALTER TABLE alloc_table ADD COLUMN gapless TINYINT(1) NOT NULL DEFAULT 0 COMMENT 'ids of gapless key are reserved one by one and never skipped' AFTER obfuscate_seed;
+ALTER TABLE alloc_table ADD COLUMN disabled TINYINT(1) NOT NULL DEFAULT 0 COMMENT 'no id can be taken from disabled key' AFTER gapless;
+
+ALTER TABLE worker_lease ADD COLUMN last_ts BIGINT NOT NULL DEFAULT 0 COMMENT 'last used timestamp unix ms' AFTER expire_at;
@@ -0,0 +1,33 @@
+-- upgrade alloc_table and worker_lease created by an older table_pg.sql to the current schema,
+-- run table_pg.sql first to create the tables which do not exist yet, then run this file with
+--   psql -h <host> -U <user> -d <db> -f upgrade_pg.sql
+-- columns which already exist are skipped so it is safe to run more than once.
+-- Upgrade the schema before rolling out the new version, the new columns have defaults
+-- which keep the behavior of existing keys.
+
+ALTER TABLE alloc_table
+  ADD COLUMN IF NOT EXISTS min_step INTEGER NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS max_step INTEGER NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS min_id BIGINT NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS max_id BIGINT NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS exhaust_policy SMALLINT NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS reset_period SMALLINT NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS time_zone VARCHAR(64) NOT NULL DEFAULT '',
+  ADD COLUMN IF NOT EXISTS id_format VARCHAR(128) NOT NULL DEFAULT '',
+  ADD COLUMN IF NOT EXISTS obfuscate_seed BIGINT NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS gapless BOOLEAN NOT NULL DEFAULT FALSE,
+  ADD COLUMN IF NOT EXISTS disabled BOOLEAN NOT NULL DEFAULT FALSE;
+COMMENT ON COLUMN alloc_table.min_step IS 'min adaptive step, 0 means not set';
+COMMENT ON COLUMN alloc_table.max_step IS 'max adaptive step, 0 means not set';
+COMMENT ON COLUMN alloc_table.min_id IS 'ids start from and cycle back to min id, 0 means 1';
+COMMENT ON COLUMN alloc_table.max_id IS 'the largest id, 0 means no limit';
+COMMENT ON COLUMN alloc_table.exhaust_policy IS 'what to do when max id is reached, 0 error, 1 cycle';
+COMMENT ON COLUMN alloc_table.reset_period IS 'how often the sequence starts over, 0 never, 1 daily, 2 monthly';
+COMMENT ON COLUMN alloc_table.time_zone IS 'time zone where periods roll over, empty means UTC';
+COMMENT ON COLUMN alloc_table.id_format IS 'template which ids are rendered with, empty means not set';
+COMMENT ON COLUMN alloc_table.obfuscate_seed IS 'secret which ids are obfuscated with, 0 means not obfuscated';
+COMMENT ON COLUMN alloc_table.gapless IS 'ids of gapless key are reserved one by one and never skipped';
+COMMENT ON COLUMN alloc_table.disabled IS 'no id can be taken from disabled key';
+
+ALTER TABLE worker_lease ADD COLUMN IF NOT EXISTS last_ts BIGINT NOT NULL DEFAULT 0;
+COMMENT ON COLUMN worker_lease.last_ts IS 'last used timestamp unix ms';
@@ -0,0 +1,361 @@
+package idgen
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/ryanreadbooks/folium/internal/pkg"
+	"github.com/ryanreadbooks/folium/internal/segment/dao"
+)
+
+// admin operations on keys, they work on the store directly and keep the buffer of this node in line
+
+const (
+	maxKeyLen       = 128
+	defaultPageSize = 100
+	maxPageSize     = 1000
+)
+
+// SegmentState is a snapshot of a segment, ids in [Cur, Max) are not dispensed yet
+type SegmentState struct {
+	Begin uint64
+	Cur   uint64
+	Max   uint64
+}
+
+// BufferState is a snapshot of the buffer of a key on this node
+type BufferState struct {
+	Current   SegmentState
+	Next      *SegmentState // nil if standby segment is not loaded
+	Loading   bool          // standby segment is being loaded
+	Step      uint32
+	MinStep   uint32
+	MaxStep   uint32
+	FetchedAt time.Time
+	IdleSince time.Time
+}
+
+// KeyInfo is the db state of a key and its buffer state on this node
+type KeyInfo struct {
+	Alloc  *dao.Alloc
+	Buffer *BufferState // nil if key has no buffer on this node
+}
+
+func segState(seg *segment) SegmentState {
+	// cur goes beyond max once segment is used up
+	return SegmentState{Begin: seg.begin, Cur: min(seg.getCur(), seg.max), Max: seg.max}
+}
+
+// state returns the snapshot of buffer
+func (b *buffer) state() *BufferState {
+	b.RLock()
+	defer b.RUnlock()
+
+	st := &BufferState{
+		Current:   segState(b.curSeg()),
+		Loading:   b.loading != nil,
+		Step:      b.step,
+		MinStep:   b.minStep,
+		MaxStep:   b.maxStep,
+		FetchedAt: b.fetchedAt,
+	}
+	if b.next != nil {
+		next := segState(b.next)
+		st.Next = &next
+	}
+
+	return st
+}
+
+// setStep applies the step and step bounds changed by admin to buffer, zero bounds fall back to the global ones
+func (b *buffer) setStep(step, keyMinStep, keyMaxStep uint32) {
+	b.Lock()
+	defer b.Unlock()
+
+	b.step = step
+	b.minStep, b.maxStep = minStep, maxStep
+	if keyMinStep != 0 {
+		b.minStep = keyMinStep
+	}
+	if keyMaxStep != 0 {
+		b.maxStep = keyMaxStep
+	}
+}
+
+func checkStep(step, minStep, maxStep uint32) error {
+	if step == 0 || step > maxStepAllowed {
+		return pkg.ErrInvalidArgs.Message(fmt.Sprintf("step should be in [1, %d]", maxStepAllowed))
+	}
+	if minStep > maxStepAllowed || maxStep > maxStepAllowed {
+		return pkg.ErrInvalidArgs.Message(fmt.Sprintf("step bounds should not be greater than %d", maxStepAllowed))
+	}
+	if minStep != 0 && maxStep != 0 && minStep > maxStep {
+		return pkg.ErrInvalidArgs.Message("min step is greater than max step")
+	}
+
+	return nil
+}
+
+// CreateKey creates key with alloc, cur_id of alloc is the first id of key and it defaults to min_id,
+// ids of key are obfuscated if alloc has a seed from NewSeed and they are only reserved by ReserveNum if alloc is gapless,
+// dao.ErrKeyExists is returned if key exists
+func CreateKey(ctx context.Context, alloc *dao.Alloc) (*dao.Alloc, error) {
+	if len(alloc.Key) == 0 || len(alloc.Key) > maxKeyLen {
+		return nil, pkg.ErrInvalidArgs.Message(fmt.Sprintf("key length should be in [1, %d]", maxKeyLen))
+	}
+	if isPeriodKey(alloc.Key) {
+		return nil, pkg.ErrInvalidArgs.Message(fmt.Sprintf("key should not contain %s", periodSep))
+	}
+
+	if err := checkStep(alloc.Step, alloc.MinStep, alloc.MaxStep); err != nil {
+		return nil, err
+	}
+
+	if err := checkSeed(alloc); err != nil {
+		return nil, err
+	}
+	if alloc.Seed != 0 && alloc.MaxId == 0 {
+		// ids of obfuscated key are bounded by the obfuscation range
+		alloc.MaxId = MaxObfuscatedId - 1
+	}
+
+	if err := checkIdBounds(alloc); err != nil {
+		return nil, err
+	}
+
+	if err := checkFormat(alloc.Format); err != nil {
+		return nil, err
+	}
+
+	if err := checkPeriod(alloc); err != nil {
+		return nil, err
+	}
+
+	if err := checkGapless(alloc); err != nil {
+		return nil, err
+	}
+
+	if err := store.CreateKey(ctx, alloc); err != nil {
+		return nil, err
+	}
+
+	return store.QueryByKey(ctx, alloc.Key)
+}
+
+func checkIdBounds(alloc *dao.Alloc) error {
+	if alloc.Exhaust != dao.ExhaustError && alloc.Exhaust != dao.ExhaustCycle {
+		return pkg.ErrInvalidArgs.Message(fmt.Sprintf("exhaust policy %d is not supported", alloc.Exhaust))
+	}
+
+	if alloc.MaxId == 0 {
+		// no limit
+		if alloc.CurId != 0 && alloc.CurId < alloc.MinId {
+			return pkg.ErrInvalidArgs.Message("cur id is less than min id")
+		}
+		return nil
+	}
+
+	if alloc.MaxId == math.MaxUint64 {
+		return pkg.ErrInvalidArgs.Message("max id is too large, zero means no limit")
+	}
+	if alloc.MinId > alloc.MaxId {
+		return pkg.ErrInvalidArgs.Message("min id is greater than max id")
+	}
+	if alloc.CurId != 0 && (alloc.CurId < alloc.MinId || alloc.CurId > alloc.MaxId) {
+		return pkg.ErrInvalidArgs.Message("cur id should be in [min id, max id]")
+	}
+
+	return nil
+}
+
+// UpdateStep changes step and step bounds of key, the buffer of key on this node takes them at once
+// while buffers on other nodes take them when they fetch their next segments
+func UpdateStep(ctx context.Context, key string, step, minStep, maxStep uint32) error {
+	if len(key) == 0 {
+		return pkg.ErrInvalidArgs.Message("key is empty")
+	}
+
+	if err := checkStep(step, minStep, maxStep); err != nil {
+		return err
+	}
+
+	if err := store.UpdateStep(ctx, key, step, minStep, maxStep); err != nil {
+		return err
+	}
+
+	if val, ok := bufs.Load(key); ok {
+		val.(*buffer).setStep(step, minStep, maxStep)
+	}
+
+	return nil
+}
+
+// UpdateFormat changes the format template of key, empty format removes it,
+// other nodes render with the new format once their cached config expires
+func UpdateFormat(ctx context.Context, key string, format string) error {
+	if len(key) == 0 {
+		return pkg.ErrInvalidArgs.Message("key is empty")
+	}
+
+	if err := checkFormat(format); err != nil {
+		return err
+	}
+
+	if err := store.UpdateFormat(ctx, key, format); err != nil {
+		return err
+	}
+	keyConfs.Delete(key)
+
+	return nil
+}
+
+// GetKey returns the db state of key and its buffer state on this node
+func GetKey(ctx context.Context, key string) (*KeyInfo, error) {
+	if len(key) == 0 {
+		return nil, pkg.ErrInvalidArgs.Message("key is empty")
+	}
+
+	alloc, err := store.QueryByKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &KeyInfo{Alloc: alloc}
+	rwMu.Lock()
+	if val, ok := bufs.Load(key); ok {
+		buf := val.(*buffer)
+		info.Buffer = buf.state()
+		info.Buffer.IdleSince = buf.idleSince
+	}
+	rwMu.Unlock()
+
+	return info, nil
+}
+
+// ListKeys returns at most pageSize keys in key order after pageToken, period rows of sequence keys are skipped,
+// the returned token is empty if there is no more key
+func ListKeys(ctx context.Context, pageToken string, pageSize int) ([]*dao.Alloc, string, error) {
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	pageSize = min(pageSize, maxPageSize)
+
+	// query one more to know if there is a next page
+	var allocs []*dao.Alloc
+	for after := pageToken; len(allocs) <= pageSize; {
+		page, err := store.QueryPage(ctx, after, pageSize+1)
+		if err != nil {
+			return nil, "", err
+		}
+		for _, alloc := range page {
+			if !isPeriodKey(alloc.Key) {
+				allocs = append(allocs, alloc)
+			}
+		}
+		if len(page) <= pageSize {
+			break
+		}
+		after = page[len(page)-1].Key
+	}
+
+	var next string
+	if len(allocs) > pageSize {
+		allocs = allocs[:pageSize]
+		next = allocs[pageSize-1].Key
+	}
+
+	return allocs, next, nil
+}
+
+// DisableKey stops dispensing ids of key, the buffer of key on this node is dropped at once
+// while buffers on other nodes stop when their current segments are used up,
+// and sequences of key on other nodes stop once their cached config expires
+func DisableKey(ctx context.Context, key string) error {
+	if len(key) == 0 {
+		return pkg.ErrInvalidArgs.Message("key is empty")
+	}
+
+	if err := store.SetDisabled(ctx, key, true); err != nil {
+		return err
+	}
+	keyConfs.Delete(key)
+
+	rwMu.Lock()
+	if val, ok := bufs.Load(key); ok {
+		evictBuffer(key, val.(*buffer))
+	}
+	rwMu.Unlock()
+
+	return nil
+}
+
+// EnableKey re-enables a disabled key
+func EnableKey(ctx context.Context, key string) error {
+	if len(key) == 0 {
+		return pkg.ErrInvalidArgs.Message("key is empty")
+	}
+
+	if err := store.SetDisabled(ctx, key, false); err != nil {
+		return err
+	}
+	keyConfs.Delete(key)
+
+	return nil
+}
+
+// JumpAhead raises cur_id of key to curId without ever lowering it, no id lower than curId is dispensed
+// by this node once it returns, buffers on other nodes keep dispensing until their current segments are used up.
+// Gapless keys and keys with reset period can not jump ahead, curId of keys with max id should be max id + 1 at most,
+// or max id if the key cycles
+func JumpAhead(ctx context.Context, key string, curId uint64) (*dao.Alloc, error) {
+	if err := checkKeyName(key); err != nil {
+		return nil, err
+	}
+
+	cur, err := store.QueryByKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if cur.Gapless {
+		return nil, dao.ErrKeyGapless.Message(fmt.Sprintf("key %s is gapless and can not jump ahead", key))
+	}
+	if cur.Period != dao.ResetNone {
+		// the sequence of each period starts over from min id
+		return nil, ErrKeyPeriodic.Message(fmt.Sprintf("key %s resets every period and can not jump ahead", key))
+	}
+	if cur.MaxId != 0 {
+		limit := cur.MaxId + 1
+		if cur.Exhaust == dao.ExhaustCycle {
+			// cycling key would go back to min id right away and dispense ids lower than curId again
+			limit = cur.MaxId
+		}
+		if curId > limit {
+			return nil, pkg.ErrInvalidArgs.Message(fmt.Sprintf("cur id should not be greater than %d", limit))
+		}
+	}
+
+	alloc, err := store.AdvanceCurId(ctx, key, curId)
+	if err != nil {
+		return nil, err
+	}
+
+	// buffer being created may have fetched its segment before cur_id is advanced, wait for it
+	rwMu.Lock()
+	call := creating[key]
+	rwMu.Unlock()
+	if call != nil {
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return nil, pkg.ErrInternal.Message(ctx.Err().Error())
+		}
+	}
+
+	if val, ok := bufs.Load(key); ok {
+		val.(*buffer).raise(curId)
+	}
+
+	return alloc, nil
+}
@@ -0,0 +1,252 @@
+package idgen
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ryanreadbooks/folium/internal/pkg"
+	"github.com/ryanreadbooks/folium/internal/segment/dao"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateKey(t *testing.T) {
+	defer clean()
+
+	alloc, err := CreateKey(ctx, &dao.Alloc{Key: "biz-admin", CurId: 5000, Step: 100})
+	assert.Nil(t, err)
+	assert.EqualValues(t, 5000, alloc.CurId)
+	assert.EqualValues(t, 100, alloc.Step)
+
+	_, err = CreateKey(ctx, &dao.Alloc{Key: "biz-admin", CurId: 1, Step: 100})
+	assert.Equal(t, dao.ErrKeyExists.Code, err.(*pkg.Err).Code)
+	_, err = CreateKey(ctx, &dao.Alloc{Key: "biz-admin-bad", CurId: 1, Step: 0})
+	assert.Equal(t, pkg.ErrInvalidArgs.Code, err.(*pkg.Err).Code)
+	_, err = CreateKey(ctx, &dao.Alloc{Key: "biz-admin-bad", CurId: 1, Step: 100, MinStep: 200, MaxStep: 10})
+	assert.Equal(t, pkg.ErrInvalidArgs.Code, err.(*pkg.Err).Code)
+	_, err = CreateKey(ctx, &dao.Alloc{Key: "biz-admin-bad", Step: 100, MinId: 200, MaxId: 10})
+	assert.Equal(t, pkg.ErrInvalidArgs.Code, err.(*pkg.Err).Code)
+	_, err = CreateKey(ctx, &dao.Alloc{Key: "biz-admin-bad", CurId: 300, Step: 100, MaxId: 200})
+	assert.Equal(t, pkg.ErrInvalidArgs.Code, err.(*pkg.Err).Code)
+	_, err = CreateKey(ctx, &dao.Alloc{Key: "biz-admin-bad", Step: 100, Exhaust: 9})
+	assert.Equal(t, pkg.ErrInvalidArgs.Code, err.(*pkg.Err).Code)
+
+	id, err := GetNext(ctx, "biz-admin")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 5000, id)
+}
+
+func TestGetKey(t *testing.T) {
+	defer clean()
+
+	_, err := GetKey(ctx, "biz-admin")
+	assert.Equal(t, dao.ErrKeyNotFound.Code, err.(*pkg.Err).Code)
+
+	_, err = CreateKey(ctx, &dao.Alloc{Key: "biz-admin", CurId: 1, Step: 100})
+	assert.Nil(t, err)
+	info, err := GetKey(ctx, "biz-admin")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1, info.Alloc.CurId)
+	assert.Nil(t, info.Buffer)
+
+	for i := 0; i < 10; i++ {
+		_, err = GetNext(ctx, "biz-admin")
+		assert.Nil(t, err)
+	}
+
+	info, err = GetKey(ctx, "biz-admin")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 101, info.Alloc.CurId)
+	assert.NotNil(t, info.Buffer)
+	assert.EqualValues(t, 1, info.Buffer.Current.Begin)
+	assert.EqualValues(t, 11, info.Buffer.Current.Cur)
+	assert.EqualValues(t, 101, info.Buffer.Current.Max)
+	assert.EqualValues(t, 100, info.Buffer.Step)
+	assert.Nil(t, info.Buffer.Next)
+}
+
+func TestUpdateStep(t *testing.T) {
+	defer clean()
+
+	err := UpdateStep(ctx, "biz-admin", 100, 0, 0)
+	assert.Equal(t, dao.ErrKeyNotFound.Code, err.(*pkg.Err).Code)
+
+	_, err = CreateKey(ctx, &dao.Alloc{Key: "biz-admin", CurId: 1, Step: 100})
+	assert.Nil(t, err)
+	_, err = GetNext(ctx, "biz-admin")
+	assert.Nil(t, err)
+
+	err = UpdateStep(ctx, "biz-admin", 500, 200, 800)
+	assert.Nil(t, err)
+
+	info, err := GetKey(ctx, "biz-admin")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 500, info.Alloc.Step)
+	assert.EqualValues(t, 200, info.Alloc.MinStep)
+	assert.EqualValues(t, 800, info.Alloc.MaxStep)
+	assert.EqualValues(t, 500, info.Buffer.Step)
+	assert.EqualValues(t, 200, info.Buffer.MinStep)
+	assert.EqualValues(t, 800, info.Buffer.MaxStep)
+}
+
+func TestListKeys(t *testing.T) {
+	defer clean()
+
+	for i := 0; i < 25; i++ {
+		_, err := CreateKey(ctx, &dao.Alloc{Key: fmt.Sprintf("biz-admin-%02d", i), CurId: 1, Step: 100})
+		assert.Nil(t, err)
+	}
+	// period rows are not listed
+	for i := 0; i < 15; i++ {
+		err := testStore.CreateKey(ctx, &dao.Alloc{Key: periodKey("biz-admin-05", fmt.Sprintf("202610%02d", i+1)), Step: 100})
+		assert.Nil(t, err)
+	}
+	_, err := CreateKey(ctx, &dao.Alloc{Key: "biz-admin-05#20261016", Step: 100})
+	assert.Equal(t, pkg.ErrInvalidArgs.Code, err.(*pkg.Err).Code)
+
+	var (
+		keys  []string
+		token string
+		pages int
+	)
+	for {
+		allocs, next, err := ListKeys(ctx, token, 10)
+		assert.Nil(t, err)
+		for _, alloc := range allocs {
+			keys = append(keys, alloc.Key)
+		}
+		pages++
+		if next == "" {
+			break
+		}
+		token = next
+	}
+
+	assert.Equal(t, 3, pages)
+	assert.Len(t, keys, 25)
+	for i, key := range keys {
+		assert.Equal(t, fmt.Sprintf("biz-admin-%02d", i), key)
+	}
+}
+
+func TestDisableKey(t *testing.T) {
+	defer clean()
+
+	_, err := CreateKey(ctx, &dao.Alloc{Key: "biz-admin", CurId: 1, Step: 100})
+	assert.Nil(t, err)
+	id, err := GetNext(ctx, "biz-admin")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1, id)
+
+	err = DisableKey(ctx, "biz-admin")
+	assert.Nil(t, err)
+	_, ok := bufs.Load("biz-admin")
+	assert.False(t, ok)
+
+	_, err = GetNext(ctx, "biz-admin")
+	assert.Equal(t, dao.ErrKeyDisabled.Code, err.(*pkg.Err).Code)
+	_, err = LeaseRange(ctx, "biz-admin", 10, "test")
+	assert.Equal(t, dao.ErrKeyDisabled.Code, err.(*pkg.Err).Code)
+
+	autoCreate = false
+	_, err = GetNext(ctx, "biz-admin")
+	autoCreate = true
+	assert.Equal(t, dao.ErrKeyDisabled.Code, err.(*pkg.Err).Code)
+
+	err = EnableKey(ctx, "biz-admin")
+	assert.Nil(t, err)
+	// the evicted segment is not reused
+	id, err = GetNext(ctx, "biz-admin")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 101, id)
+}
+
+func TestJumpAhead(t *testing.T) {
+	defer clean()
+
+	_, err := JumpAhead(ctx, "biz-admin", 5000)
+	assert.Equal(t, dao.ErrKeyNotFound.Code, err.(*pkg.Err).Code)
+
+	_, err = CreateKey(ctx, &dao.Alloc{Key: "biz-admin", CurId: 1, Step: 100})
+	assert.Nil(t, err)
+	id, err := GetNext(ctx, "biz-admin")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1, id)
+
+	alloc, err := JumpAhead(ctx, "biz-admin", 50)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 101, alloc.CurId)
+	// ids above the target in the current segment are still dispensed
+	id, err = GetNext(ctx, "biz-admin")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 50, id)
+
+	alloc, err = JumpAhead(ctx, "biz-admin", 5000)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 5000, alloc.CurId)
+	ids, err := GetNextBatch(ctx, "biz-admin", 10)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 5000, ids[0])
+
+	// cur_id is not lowered
+	alloc, err = JumpAhead(ctx, "biz-admin", 10)
+	assert.Nil(t, err)
+	assert.Less(t, uint64(5000), alloc.CurId)
+	id, err = GetNext(ctx, "biz-admin")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 5010, id)
+}
+
+func TestJumpAhead_maxId(t *testing.T) {
+	defer clean()
+
+	_, err := CreateKey(ctx, &dao.Alloc{Key: "biz-admin", Step: 10, MaxId: 100, Exhaust: dao.ExhaustCycle})
+	assert.Nil(t, err)
+	id, err := GetNext(ctx, "biz-admin")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1, id)
+
+	alloc, err := JumpAhead(ctx, "biz-admin", 95)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 95, alloc.CurId)
+	id, err = GetNext(ctx, "biz-admin")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 95, id)
+
+	// jumping beyond max id would cycle back to lower ids
+	_, err = JumpAhead(ctx, "biz-admin", 500)
+	assert.Equal(t, pkg.ErrInvalidArgs.Code, err.(*pkg.Err).Code)
+	_, err = JumpAhead(ctx, "biz-admin", 101)
+	assert.Equal(t, pkg.ErrInvalidArgs.Code, err.(*pkg.Err).Code)
+	_, err = testStore.AdvanceCurId(ctx, "biz-admin", 500)
+	assert.Equal(t, dao.ErrCurIdTooHigh, err)
+	id, err = GetNext(ctx, "biz-admin")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 96, id)
+
+	// key which does not cycle may jump to max id + 1 to be exhausted
+	_, err = CreateKey(ctx, &dao.Alloc{Key: "biz-admin-max", Step: 10, MaxId: 100})
+	assert.Nil(t, err)
+	_, err = JumpAhead(ctx, "biz-admin-max", 101)
+	assert.Nil(t, err)
+	_, err = GetNext(ctx, "biz-admin-max")
+	assert.Equal(t, dao.ErrKeyExhausted.Code, err.(*pkg.Err).Code)
+}
+
+func TestJumpAhead_period(t *testing.T) {
+	defer clean()
+
+	_, err := CreateKey(ctx, &dao.Alloc{Key: "biz-admin", Step: 10, Period: dao.ResetDaily})
+	assert.Nil(t, err)
+	_, seq, err := GetNextSeq(ctx, "biz-admin")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1, seq)
+
+	_, err = JumpAhead(ctx, "biz-admin", 100)
+	assert.Equal(t, ErrKeyPeriodic.Code, err.(*pkg.Err).Code)
+	_, err = JumpAhead(ctx, periodKey("biz-admin", "20261018"), 100)
+	assert.Equal(t, pkg.ErrInvalidArgs.Code, err.(*pkg.Err).Code)
+
+	_, seq, err = GetNextSeq(ctx, "biz-admin")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 2, seq)
+}
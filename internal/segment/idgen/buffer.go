@@ -4,141 +4,314 @@ import (
 	"context"
 	"log"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"github.com/ryanreadbooks/folium/internal/segment/dao"
 )
 
 const (
-	watermark = 0.85
+	defaultWatermark  = 0.85
+	defaultMinStep    = 100
+	defaultMaxStep    = maxStepAllowed
+	defaultStepWindow = time.Minute * 15
 )
 
-// buffer holds two segments which dispense ids
+// buffer holds the current segment and the standby one,
+// the standby segment is loaded in background once the current one hits watermark.
+//
+// Ids are taken from the current segment without lock,
+// the lock is only held when segments are swapped or loaded.
 type buffer struct {
 	sync.RWMutex
 
 	key  string
-	cur  *segment // cur points to seg1 or seg2
-	seg1 *segment
-	seg2 *segment
+	cur  atomic.Pointer[segment]
+	next *segment // standby segment, nil if it is not loaded yet
+
+	watermark float64
+	loading   chan struct{} // not nil while standby segment is being loaded, closed when loading is done
+	floor     uint64        // ids below floor are skipped in the standby segment being loaded, zero if not set
+	loadCh    chan struct{} // notify worker to load standby segment
+	store     dao.AllocStore
+	obf       *feistel // nil if ids of key are not obfuscated, it never changes once buffer is created
+
+	// step for changing the step in db, it grows if a segment is used up within window
+	// and shrinks if a segment lasts longer than twice the window
+	step      uint32
+	minStep   uint32
+	maxStep   uint32
+	window    time.Duration // non-positive window disables adaptive step
+	fetchedAt time.Time     // when the latest segment is fetched
+
+	touched   atomic.Bool // buffer is used since the last sweep
+	idleSince time.Time   // guarded by rwMu
 
-	closeCh chan struct{}
-	step    uint32 // step for changing the step in db
-	ctx     context.Context
-	cancel  context.CancelFunc
+	closeCh   chan struct{}
+	doneCh    chan struct{} // closed when worker exits
+	closeOnce sync.Once
+	ctx       context.Context
+	cancel    context.CancelFunc
 }
 
-func newBuffer(ctx context.Context, key string, step uint32) (*buffer, error) {
-	seg1 := newSegment(key)
-	err := seg1.fetchDB(ctx, step) // need to be synced with db
+func newBuffer(ctx context.Context, key string, step uint32, store dao.AllocStore) (*buffer, error) {
+	cctx, ccancel := context.WithCancel(context.Background())
+	b := &buffer{
+		key:       key,
+		watermark: watermark,
+		loadCh:    make(chan struct{}, 1),
+		store:     store,
+		window:    stepWindow,
+		closeCh:   make(chan struct{}),
+		doneCh:    make(chan struct{}),
+		ctx:       cctx,
+		cancel:    ccancel,
+	}
+
+	seg, res, err := b.load(ctx, step) // need to be synced with db
 	if err != nil {
+		ccancel()
 		return nil, err
 	}
-	seg1.name = "seg1"
-	log.Printf("seg1 loaded with %+v\n", seg1)
-	seg2 := newSegment(key)
-	seg2.name = "seg2"
-
-	cctx, ccancel := context.WithCancel(context.Background())
-	b := &buffer{
-		key:     key,
-		seg1:    seg1,
-		seg2:    seg2, // we do not fetchDB in the first place
-		cur:     seg1,
-		closeCh: make(chan struct{}),
-		step:    step,
-		ctx:     cctx,
-		cancel:  ccancel,
+	log.Printf("buffer %s loaded with %+v\n", key, seg)
+	b.fetched(res)
+	b.cur.Store(seg)
+	if res.Seed != 0 {
+		b.obf = newFeistel(res.Seed)
 	}
 
-	// go b.worker()
+	go b.worker()
 
 	return b, nil
 }
 
-// swap without lock
-func (b *buffer) swap(ctx context.Context) error {
-	if b.cur == b.seg1 {
-		// make sure we are swapping into maxinum segment
-		if b.seg1.max > b.seg2.max {
-			err := b.seg2.fetchDB(ctx, b.step)
-			if err != nil {
-				log.Printf("buffer swap to seg2 fetchDB err: %v\n", err)
-				return err
-			}
-			log.Printf("buffer swap to seg2 fetchDB: %+v\n", b.seg2)
+// load a new segment from db
+func (b *buffer) load(ctx context.Context, step uint32) (*segment, *dao.TakeIdResult, error) {
+	seg := newSegment(b.key, b.store)
+	res, err := seg.fetchDB(ctx, step)
+	if err != nil {
+		return nil, nil, err
+	}
+	seg.setMark(b.watermark)
+	return seg, res, nil
+}
+
+// swap replaces used up segment old with the standby one,
+// it waits for the standby segment if it is being loaded or loads it in place,
+// it always loads in place once buffer is closed as there is no worker to preload
+func (b *buffer) swap(ctx context.Context, old *segment) error {
+	b.Lock()
+	defer b.Unlock()
+
+	for {
+		if b.cur.Load() != old {
+			// swapped by others
+			return nil
 		}
-		b.cur = b.seg2
-	} else {
-		if b.seg1.max < b.seg2.max {
-			err := b.seg1.fetchDB(ctx, b.step)
-			if err != nil {
-				log.Printf("buffer swap to seg1 fetchDB err: %v\n", err)
-				return err
-			}
-			log.Printf("buffer swap to seg1 fetchDB: %+v\n", b.seg1)
+
+		if b.next != nil {
+			b.cur.Store(b.next)
+			b.next = nil
+			return nil
+		}
+
+		loading := b.loading
+		if loading == nil {
+			break
 		}
-		b.cur = b.seg1
+
+		// standby segment is being loaded in background, wait for it
+		b.Unlock()
+		select {
+		case <-loading:
+		case <-ctx.Done():
+			b.Lock()
+			return ctx.Err()
+		}
+		b.Lock()
 	}
 
+	// preloading is not started or failed, load standby segment in place
+	seg, res, err := b.load(ctx, b.nextStep())
+	if err != nil {
+		log.Printf("buffer %s swap fetchDB err: %v\n", b.key, err)
+		return err
+	}
+	log.Printf("buffer swap fetchDB: %+v\n", seg)
+	b.fetched(res)
+	b.cur.Store(seg)
+
 	return nil
 }
 
 func (b *buffer) curSeg() *segment {
-	return b.cur
+	return b.cur.Load()
 }
 
-func (b *buffer) bakSeg() *segment {
-	if b.cur == b.seg1 {
-		return b.seg2
-	}
-	return b.seg1
+// standby segment is ready to be swapped in, it should be called with lock held
+func (b *buffer) bakReady() bool {
+	return b.next != nil
 }
 
 func (b *buffer) getId(ctx context.Context) (uint64, error) {
 	for {
-		b.Lock()
-		curSeg := b.curSeg()
-		val := curSeg.nextAndIncr()
-		if val < curSeg.max {
-			b.Unlock()
+		seg := b.curSeg()
+		val := seg.nextAndIncr()
+		if val < seg.max {
+			if val == seg.mark {
+				b.notifyMark()
+			}
 			return val, nil
 		}
 
 		// val is overflow, we need to switch segment and get the next id again
-		var err error = b.swap(ctx)
+		err := b.swap(ctx, seg)
 		if err != nil {
 			log.Printf("buffer getId swap err: %v\n", err)
-			b.Unlock()
 			return 0, err
 		}
-		b.Unlock()
 	}
 }
 
-// preload will check and do swapping stuff after get Id
-// just to prevent worker is not working properly
-func (b *buffer) preload() {
-	defer func() {
-		if err := recover(); err != nil {
-			log.Printf("buffer postGetId panic: %v\n", err)
+// getIds returns at most n ids, segments are swapped if needed,
+// ids are ascending but may not be contiguous if others are taking ids concurrently
+func (b *buffer) getIds(ctx context.Context, n int) ([]uint64, error) {
+	ids := make([]uint64, 0, n)
+	for len(ids) < n {
+		seg := b.curSeg()
+		from, to := seg.take(uint64(n - len(ids)))
+		for id := from; id < to; id++ {
+			ids = append(ids, id)
 		}
-	}()
+		if from <= seg.mark && seg.mark < to {
+			b.notifyMark()
+		}
+		if len(ids) == n {
+			break
+		}
+
+		// current segment is used up, swap and continue
+		err := b.swap(ctx, seg)
+		if err != nil {
+			log.Printf("buffer getIds swap err: %v\n", err)
+			if len(ids) != 0 {
+				// return what we have got
+				return ids, nil
+			}
+			return nil, err
+		}
+	}
+
+	return ids, nil
+}
 
+func (b *buffer) notifyMark() {
 	b.Lock()
 	defer b.Unlock()
+	b.checkMark()
+}
 
-	// we hit watermark
-	if b.curSeg().hitMark(watermark) {
-		// load the other segment
-		err := b.bakSeg().fetchDB(b.ctx, b.step)
-		if err != nil {
-			log.Printf("buffer postGetId fetchDB err: %v\n", err)
-			return
-		}
-		log.Printf("buffer loaded segment updated: %+v\n", b.bakSeg())
+// checkMark notifies worker to load standby segment if current segment hits watermark,
+// nothing is preloaded once buffer is closed. It should be called with lock held
+func (b *buffer) checkMark() {
+	if b.ctx.Err() != nil || b.loading != nil || b.bakReady() || !b.curSeg().hitMark(b.watermark) {
+		return
+	}
+
+	b.loading = make(chan struct{})
+	select {
+	case b.loadCh <- struct{}{}:
+	default:
 	}
 }
 
-// worker the situation of two segments
+// preload loads standby segment from db without holding the lock,
+// so that ids can still be dispensed from the current segment in the meantime
+func (b *buffer) preload() {
+	b.Lock()
+	done := b.loading
+	if done == nil {
+		b.Unlock()
+		return
+	}
+	step := b.nextStep()
+	b.Unlock()
+
+	seg, res, err := b.load(b.ctx, step)
+
+	b.Lock()
+	defer b.Unlock()
+	if err != nil {
+		log.Printf("buffer preload fetchDB err: %v\n", err)
+	} else {
+		// key may jump ahead while loading
+		seg.raise(b.floor)
+		b.next = seg
+		b.fetched(res)
+		log.Printf("buffer loaded segment updated: %+v\n", seg)
+	}
+	b.floor = 0
+	b.loading = nil
+	close(done)
+}
+
+// nextStep returns the step for the next segment according to how long the latest segment lasts,
+// it should be called with lock held
+func (b *buffer) nextStep() uint32 {
+	if b.window <= 0 {
+		return b.step
+	}
+
+	step := uint64(b.step)
+	elapsed := time.Since(b.fetchedAt)
+	if elapsed < b.window {
+		step *= 2
+	} else if elapsed > b.window*2 {
+		step /= 2
+	}
+	step = min(max(step, uint64(b.minStep)), uint64(b.maxStep))
+
+	if step != uint64(b.step) {
+		log.Printf("buffer %s step adjusted from %d to %d, latest segment lasted %v\n", b.key, b.step, step, elapsed)
+	}
+	return uint32(step)
+}
+
+// fetched records the step and step bounds of the segment just fetched,
+// it should be called with lock held
+func (b *buffer) fetched(res *dao.TakeIdResult) {
+	b.step = res.Step
+	b.fetchedAt = time.Now()
+
+	// bounds of key take precedence
+	b.minStep, b.maxStep = minStep, maxStep
+	if res.MinStep != 0 {
+		b.minStep = res.MinStep
+	}
+	if res.MaxStep != 0 {
+		b.maxStep = res.MaxStep
+	}
+}
+
+// raise makes sure no id below floor is dispensed from now on,
+// the ids below floor in current and standby segments are skipped.
+// Segments loaded afterwards are not touched, ids in them may be below floor again if key cycles
+func (b *buffer) raise(floor uint64) {
+	b.Lock()
+	defer b.Unlock()
+
+	if b.loading != nil {
+		// the segment being loaded may be taken before cur_id is advanced
+		b.floor = max(b.floor, floor)
+	}
+	b.curSeg().raise(floor)
+	if b.next != nil {
+		b.next.raise(floor)
+	}
+}
+
+// worker loads standby segment when notified,
+// watermark is also checked periodically just in case notification is missed
 func (b *buffer) worker() {
 	defer func() {
 		if err := recover(); err != nil {
@@ -151,16 +324,43 @@ func (b *buffer) worker() {
 	defer ticker.Stop()
 	for {
 		select {
-		case <-ticker.C:
+		case <-b.loadCh:
 			b.preload()
+		case <-ticker.C:
+			b.notifyMark()
 		case <-b.closeCh:
-			log.Println("buffer worker exited")
-			b.cancel()
+			b.abortLoading()
+			log.Printf("buffer %s worker exited\n", b.key)
+			close(b.doneCh)
 			return
 		}
 	}
 }
 
+// abortLoading wakes up the callers waiting for a preload which is never done by the exiting worker,
+// they load the standby segment in place instead
+func (b *buffer) abortLoading() {
+	b.Lock()
+	defer b.Unlock()
+
+	if b.loading != nil {
+		close(b.loading)
+		b.loading = nil
+		b.floor = 0
+	}
+}
+
+// mark buffer as used, the flag is only written when it changes so that the fast path is not contended
+func (b *buffer) touch() {
+	if !b.touched.Load() {
+		b.touched.Store(true)
+	}
+}
+
+// close stops worker and cancels the loading in progress, it can be called more than once
 func (b *buffer) close() {
-	b.closeCh <- struct{}{}
+	b.closeOnce.Do(func() {
+		b.cancel()
+		close(b.closeCh)
+	})
 }
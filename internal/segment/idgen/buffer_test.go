@@ -1,27 +1,34 @@
 package idgen
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"math/rand"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/ryanreadbooks/folium/internal/pkg"
 	"github.com/ryanreadbooks/folium/internal/pkg/misc"
+	"github.com/ryanreadbooks/folium/internal/segment/dao"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestBuffer_newBuffer(t *testing.T) {
 	defer clean()
 
-	_, err := newBuffer(ctx, "biz-test", 0)
+	buf, err := newBuffer(ctx, "biz-test", 0, testStore)
 	assert.Nil(t, err)
+	buf.close()
 }
 
 func TestBuffer_getId(t *testing.T) {
 	defer clean()
 
-	buf, err := newBuffer(ctx, "biz-test", 0)
+	buf, err := newBuffer(ctx, "biz-test", 0, testStore)
 	assert.Nil(t, err)
+	defer buf.close()
 	assert.NotNil(t, buf)
 
 	id, err := buf.getId(ctx)
@@ -32,8 +39,9 @@ func TestBuffer_getId(t *testing.T) {
 func TestBuffer_concurrentGetId(t *testing.T) {
 	defer clean()
 
-	buf, err := newBuffer(ctx, "biz-test", 0)
+	buf, err := newBuffer(ctx, "biz-test", 0, testStore)
 	assert.Nil(t, err)
+	defer buf.close()
 	assert.NotNil(t, buf)
 
 	var wg sync.WaitGroup
@@ -59,3 +67,319 @@ func TestBuffer_concurrentGetId(t *testing.T) {
 	assert.EqualValues(t, false, misc.HasDupElems(ids))
 	// t.Logf("ids = %v\n", ids)
 }
+
+func TestBuffer_getIds(t *testing.T) {
+	defer clean()
+
+	buf, err := newBuffer(ctx, "biz-test", 0, testStore)
+	assert.Nil(t, err)
+	defer buf.close()
+	assert.NotNil(t, buf)
+
+	// cross segments
+	ids, err := buf.getIds(ctx, 2500)
+	assert.Nil(t, err)
+	assert.Len(t, ids, 2500)
+	assert.EqualValues(t, false, misc.HasDupElems(ids))
+	for i := 1; i < len(ids); i++ {
+		assert.Greater(t, ids[i], ids[i-1])
+	}
+
+	id, err := buf.getId(ctx)
+	assert.Nil(t, err)
+	assert.Greater(t, id, ids[len(ids)-1])
+}
+
+func TestBuffer_newBufferErr(t *testing.T) {
+	defer clean()
+
+	ms := memStore(t)
+	ms.SetHook(dao.FailOn(errors.New("db down")))
+	buf, err := newBuffer(ctx, "biz-test", 0, testStore)
+	assert.Nil(t, buf)
+	assert.Equal(t, pkg.ErrDb.Code, err.(*pkg.Err).Code)
+}
+
+func TestBuffer_swapErr(t *testing.T) {
+	defer clean()
+
+	ms := memStore(t)
+	buf, err := newBuffer(ctx, "biz-test", 0, testStore)
+	assert.Nil(t, err)
+	defer buf.close()
+
+	// make db fail then use up the first segment, preloading fails as well
+	seg := buf.curSeg()
+	ms.SetHook(dao.FailOn(errors.New("db down"), "TakeIdForKey"))
+	ids, err := buf.getIds(ctx, 1000)
+	assert.Nil(t, err)
+	assert.Len(t, ids, 1000)
+
+	_, err = buf.getId(ctx)
+	assert.Equal(t, pkg.ErrDb.Code, err.(*pkg.Err).Code)
+	assert.Equal(t, seg, buf.curSeg())
+
+	ids, err = buf.getIds(ctx, 10)
+	assert.NotNil(t, err)
+	assert.Empty(t, ids)
+
+	// buffer recovers once db is back and no id is handed out twice
+	ms.SetHook(nil)
+	id, err := buf.getId(ctx)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1001, id)
+}
+
+func TestBuffer_swapTimeout(t *testing.T) {
+	defer clean()
+
+	ms := memStore(t)
+	buf, err := newBuffer(ctx, "biz-test", 0, testStore)
+	assert.Nil(t, err)
+	defer buf.close()
+
+	ms.SetHook(dao.WithLatency(time.Second))
+	_, err = buf.getIds(ctx, 1000)
+	assert.Nil(t, err)
+
+	// swapping waits for the slow preloading until ctx is done
+	tctx, cancel := context.WithTimeout(ctx, time.Millisecond*20)
+	defer cancel()
+	_, err = buf.getId(tctx)
+	assert.NotNil(t, err)
+}
+
+func TestBuffer_preload(t *testing.T) {
+	defer clean()
+
+	ms := memStore(t)
+	buf, err := newBuffer(ctx, "biz-test", 0, testStore)
+	assert.Nil(t, err)
+	defer buf.close()
+
+	// not preloaded before watermark
+	_, err = buf.getIds(ctx, 800)
+	assert.Nil(t, err)
+	time.Sleep(time.Millisecond * 50)
+	buf.RLock()
+	assert.False(t, buf.bakReady())
+	buf.RUnlock()
+
+	_, err = buf.getIds(ctx, 100)
+	assert.Nil(t, err)
+	assert.Eventually(t, func() bool {
+		buf.RLock()
+		defer buf.RUnlock()
+		return buf.bakReady()
+	}, time.Second, time.Millisecond*10)
+
+	// swapping to the preloaded segment does not touch db
+	ms.SetHook(dao.FailOn(errors.New("db down")))
+	ids, err := buf.getIds(ctx, 200)
+	assert.Nil(t, err)
+	assert.Len(t, ids, 200)
+	assert.EqualValues(t, 1100, ids[len(ids)-1])
+	assert.EqualValues(t, 1001, buf.curSeg().begin)
+}
+
+func TestBuffer_closed(t *testing.T) {
+	defer clean()
+
+	buf, err := newBuffer(ctx, "biz-test", 0, testStore)
+	assert.Nil(t, err)
+
+	// preload is notified but the worker exits before doing it
+	buf.Lock()
+	buf.loading = make(chan struct{})
+	buf.Unlock()
+	buf.close()
+	<-buf.doneCh
+
+	// callers holding the closed buffer load segments in place
+	tctx, cancel := context.WithTimeout(ctx, time.Second*3)
+	defer cancel()
+	ids, err := buf.getIds(tctx, 2500)
+	assert.Nil(t, err)
+	assert.Len(t, ids, 2500)
+	for i := 0; i < 1000; i++ {
+		_, err = buf.getId(tctx)
+		assert.Nil(t, err)
+	}
+	buf.RLock()
+	assert.Nil(t, buf.loading)
+	buf.RUnlock()
+}
+
+func TestBuffer_raise(t *testing.T) {
+	defer clean()
+
+	ms := memStore(t)
+	buf, err := newBuffer(ctx, "biz-test", 0, testStore)
+	assert.Nil(t, err)
+	defer buf.close()
+
+	// raised while standby segment [1001, 2001) is being loaded
+	ms.SetHook(func(ctx context.Context, op string) error {
+		if op == "TakeIdForKey" {
+			buf.raise(1500)
+		}
+		return nil
+	})
+	_, err = buf.getIds(ctx, 900)
+	assert.Nil(t, err)
+	assert.Eventually(t, func() bool {
+		buf.RLock()
+		defer buf.RUnlock()
+		return buf.bakReady()
+	}, time.Second, time.Millisecond*10)
+	assert.True(t, buf.curSeg().overflow())
+
+	ms.SetHook(nil)
+	id, err := buf.getId(ctx)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1500, id)
+
+	// ids in current segment above floor are kept
+	buf.raise(1800)
+	id, err = buf.getId(ctx)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1800, id)
+
+	// segment is used up if floor is beyond it
+	buf.raise(3000)
+	id, err = buf.getId(ctx)
+	assert.Nil(t, err)
+	assert.GreaterOrEqual(t, id, uint64(3000))
+}
+
+func TestBuffer_nextStep(t *testing.T) {
+	defer clean()
+
+	buf, err := newBuffer(ctx, "biz-test", 1000, testStore)
+	assert.Nil(t, err)
+	defer buf.close()
+	buf.Lock()
+	defer buf.Unlock()
+
+	// used up within window
+	buf.window = time.Hour
+	assert.EqualValues(t, 2000, buf.nextStep())
+
+	// lasts longer than twice the window
+	buf.window = time.Nanosecond
+	time.Sleep(time.Millisecond)
+	assert.EqualValues(t, 500, buf.nextStep())
+
+	// kept within bounds
+	buf.minStep = 800
+	assert.EqualValues(t, 800, buf.nextStep())
+	buf.window = time.Hour
+	buf.maxStep = 1500
+	assert.EqualValues(t, 1500, buf.nextStep())
+
+	buf.window = 0
+	assert.EqualValues(t, 1000, buf.nextStep())
+}
+
+func TestBuffer_adaptiveStep(t *testing.T) {
+	defer clean()
+
+	// bounds of key
+	err := testStore.CreateUpdate(ctx, &dao.Alloc{Key: "biz-test", CurId: 1, Step: 1000, MinStep: 500, MaxStep: 3000})
+	assert.Nil(t, err)
+
+	buf, err := newBuffer(ctx, "biz-test", 1000, testStore)
+	assert.Nil(t, err)
+	defer buf.close()
+	buf.Lock()
+	buf.window = time.Hour
+	buf.Unlock()
+
+	// segments are used up quickly so step keeps growing until max step of key
+	var steps []uint64
+	for i := 0; i < 4; i++ {
+		// swap to the next segment then use it up
+		_, err = buf.getId(ctx)
+		assert.Nil(t, err)
+		seg := buf.curSeg()
+		steps = append(steps, seg.max-seg.begin)
+		_, err = buf.getIds(ctx, int(steps[i])-1)
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, []uint64{1000, 2000, 3000, 3000}, steps)
+
+	alloc, err := testStore.QueryByKey(ctx, "biz-test")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 3000, alloc.Step)
+}
+
+// mutexBuffer is the dispensing before the lock-free path, copied in as the baseline of benchmark:
+// every id is taken under the lock of buffer. Segments are refilled in place instead of being preloaded
+// by a worker, which happens once every step ids
+type mutexBuffer struct {
+	sync.Mutex
+
+	key string
+	cur uint64
+	max uint64
+}
+
+func (b *mutexBuffer) getId(ctx context.Context) (uint64, error) {
+	b.Lock()
+	defer b.Unlock()
+
+	for {
+		val := b.cur
+		b.cur++
+		if val < b.max {
+			return val, nil
+		}
+
+		// val is overflow, refill the segment and get the next id again
+		res, err := testStore.TakeIdForKey(ctx, b.key, 0)
+		if err != nil {
+			return 0, err
+		}
+		b.cur, b.max = res.Begin, res.End
+	}
+}
+
+func benchmarkGetId(b *testing.B, goroutines int, getId func() (uint64, error)) {
+	per := b.N/goroutines + 1
+	var wg sync.WaitGroup
+	b.ResetTimer()
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < per; j++ {
+				if _, err := getId(); err != nil {
+					b.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkBuffer_getId(b *testing.B) {
+	for _, n := range []int{1, 8, 64} {
+		b.Run(fmt.Sprintf("mutex-%d", n), func(b *testing.B) {
+			defer clean()
+
+			buf := &mutexBuffer{key: "biz-bench"}
+			benchmarkGetId(b, n, func() (uint64, error) { return buf.getId(ctx) })
+		})
+		b.Run(fmt.Sprintf("lockfree-%d", n), func(b *testing.B) {
+			defer clean()
+
+			buf, err := newBuffer(ctx, "biz-bench", 0, testStore)
+			if err != nil {
+				b.Fatal(err)
+			}
+			defer buf.close()
+			benchmarkGetId(b, n, func() (uint64, error) { return buf.getId(ctx) })
+		})
+	}
+}
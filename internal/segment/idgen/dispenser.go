@@ -2,8 +2,12 @@ package idgen
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/ryanreadbooks/folium/internal/pkg"
 	"github.com/ryanreadbooks/folium/internal/segment/dao"
@@ -11,24 +15,95 @@ import (
 )
 
 const (
-	maxStepAllowed = 100000
+	maxStepAllowed  = 100000
+	maxBatchAllowed = 10000
+	maxLeaseAllowed = 10000000
 )
 
 var (
-	rwMu   sync.Mutex
-	closed atomic.Bool
+	rwMu     sync.Mutex
+	closed   atomic.Bool
+	creating = make(map[string]*bufCall) // keys whose buffers are being created, guarded by rwMu
 
-	bufs sync.Map
+	bufs       sync.Map
+	store      dao.AllocStore
+	watermark         = defaultWatermark
+	minStep    uint32 = defaultMinStep
+	maxStep    uint32 = defaultMaxStep
+	stepWindow        = defaultStepWindow
+	autoCreate bool
 )
 
 var (
-	ErrClosed = pkg.NewErr(int(codes.Unavailable), "segment idgen dispenser is closed")
+	ErrClosed      = pkg.NewErr(int(codes.Unavailable), "segment idgen dispenser is closed")
+	ErrKeyPeriodic = pkg.NewErr(int(codes.FailedPrecondition), "key resets every period, take its ids by NextSeq")
 )
 
-// init idgen
-func Init() {
-	dao.InitDB()
+type Config struct {
+	Store     dao.AllocStore // store where segments are taken from
+	Watermark float64        // standby segment is preloaded once this ratio of current segment is consumed
+
+	// step of a key grows if its segment is used up within StepWindow and shrinks if it lasts longer than twice the window,
+	// step is kept in [MinStep, MaxStep] unless the key has its own bounds, negative StepWindow disables adaptive step
+	MinStep    uint32
+	MaxStep    uint32
+	StepWindow time.Duration
+
+	// buffer of a key is evicted if the key is not requested for IdleTTL, negative IdleTTL disables it,
+	// least recently used buffers are evicted if there are more than MaxBuffers keys, negative MaxBuffers means no limit
+	IdleTTL    time.Duration
+	MaxBuffers int
+
+	// idgen is not ready until WarmUp is done if WarmUp is set
+	WarmUp bool
+
+	// keys which do not exist in store are created on their first request if AutoCreate is set,
+	// otherwise dao.ErrKeyNotFound is returned for them
+	AutoCreate bool
+}
+
+// init idgen with the store where segments are taken from
+func Init(c Config) error {
+	if c.Store == nil {
+		return fmt.Errorf("segment idgen store is required")
+	}
+
+	if c.Watermark == 0 {
+		c.Watermark = defaultWatermark
+	}
+	if c.Watermark < 0 || c.Watermark > 1 {
+		return fmt.Errorf("segment watermark %v should be in (0, 1]", c.Watermark)
+	}
+
+	if c.MinStep == 0 {
+		c.MinStep = defaultMinStep
+	}
+	if c.MaxStep == 0 {
+		c.MaxStep = defaultMaxStep
+	}
+	if c.MinStep > c.MaxStep {
+		return fmt.Errorf("segment min step %d is greater than max step %d", c.MinStep, c.MaxStep)
+	}
+	if c.StepWindow == 0 {
+		c.StepWindow = defaultStepWindow
+	}
+	if c.IdleTTL == 0 {
+		c.IdleTTL = defaultIdleTTL
+	}
+	if c.MaxBuffers == 0 {
+		c.MaxBuffers = defaultMaxBuffers
+	}
+
+	store = c.Store
+	watermark = c.Watermark
+	minStep, maxStep = c.MinStep, c.MaxStep
+	stepWindow = c.StepWindow
+	idleTTL, maxBuffers = c.IdleTTL, c.MaxBuffers
 	closed.Store(false)
+	ready.Store(!c.WarmUp)
+	autoCreate = c.AutoCreate
+	startJanitor()
+	return nil
 }
 
 type GetOption struct {
@@ -48,12 +123,130 @@ func WithStep(step uint32) Option {
 
 // GetNext returns the next id for key
 func GetNext(ctx context.Context, key string, opt ...Option) (uint64, error) {
+	if err := checkKeyName(key); err != nil {
+		return 0, err
+	}
+
+	return getNext(ctx, key, opt...)
+}
+
+// getNext is GetNext without checking key name, period rows of sequences are dispensed by it
+func getNext(ctx context.Context, key string, opt ...Option) (uint64, error) {
+	buf, err := getBuffer(ctx, key, opt...)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := buf.getId(ctx)
+	if err != nil {
+		return 0, keyErr(err)
+	}
+
+	if buf.obf != nil {
+		if id, err = buf.obf.obfuscate(id); err != nil {
+			return 0, keyErr(err)
+		}
+	}
+
+	return id, nil
+}
+
+// GetNextBatch returns at most count ids for key
+func GetNextBatch(ctx context.Context, key string, count uint32, opt ...Option) ([]uint64, error) {
+	if count == 0 {
+		return nil, pkg.ErrInvalidArgs.Message("count is zero")
+	}
+
+	if count > maxBatchAllowed {
+		count = maxBatchAllowed
+	}
+
+	if err := checkKeyName(key); err != nil {
+		return nil, err
+	}
+
+	buf, err := getBuffer(ctx, key, opt...)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := buf.getIds(ctx, int(count))
+	if err != nil {
+		return nil, keyErr(err)
+	}
+
+	if buf.obf != nil {
+		for i := range ids {
+			if ids[i], err = buf.obf.obfuscate(ids[i]); err != nil {
+				return nil, keyErr(err)
+			}
+		}
+	}
+
+	return ids, nil
+}
+
+// LeaseRange takes a contiguous range [Begin, End) of size ids for key directly from db,
+// the range is shorter if max id of key is reached, who identifies the caller which receives the range.
+// Obfuscated keys can not be leased
+func LeaseRange(ctx context.Context, key string, size uint32, who string) (*dao.TakeIdResult, error) {
 	if closed.Load() {
-		return 0, ErrClosed
+		return nil, ErrClosed
+	}
+
+	if err := checkKeyName(key); err != nil {
+		return nil, err
+	}
+
+	if size == 0 || size > maxLeaseAllowed {
+		return nil, pkg.ErrInvalidArgs.Message(fmt.Sprintf("size should be in [1, %d]", maxLeaseAllowed))
+	}
+
+	if err := checkKey(ctx, key); err != nil {
+		return nil, err
+	}
+
+	// contiguous ranges would reveal what obfuscation hides, seed of key never changes so the cached config is enough
+	conf, err := getKeyConf(ctx, key)
+	if err != nil {
+		var pkgErr *pkg.Err
+		if !autoCreate || !errors.As(err, &pkgErr) || pkgErr.Code != dao.ErrKeyNotFound.Code {
+			return nil, err
+		}
+	}
+	if conf != nil && conf.alloc.Seed != 0 {
+		return nil, errLeaseObfuscated(key)
+	}
+
+	res, err := store.LeaseRange(ctx, key, size)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Seed != 0 {
+		// key is created with a seed after its config is queried, the range taken is dropped
+		return nil, errLeaseObfuscated(key)
+	}
+
+	log.Printf("range [%d, %d) of key %s leased to %s\n", res.Begin, res.End, key, who)
+
+	return res, nil
+}
+
+// bufCall is an in-flight buffer creation which concurrent callers of the same key wait for
+type bufCall struct {
+	done chan struct{}
+	buf  *buffer
+	err  error
+}
+
+func getBuffer(ctx context.Context, key string, opt ...Option) (*buffer, error) {
+	if closed.Load() {
+		return nil, ErrClosed
 	}
 
 	if len(key) == 0 {
-		return 0, pkg.ErrInvalidArgs.Message("key is empty")
+		return nil, pkg.ErrInvalidArgs.Message("key is empty")
 	}
 
 	gOpt := &GetOption{}
@@ -61,36 +254,117 @@ func GetNext(ctx context.Context, key string, opt ...Option) (uint64, error) {
 		o(gOpt)
 	}
 
-	var (
-		buf *buffer
-		ok  bool
-		err error
-	)
+	if val, ok := bufs.Load(key); ok {
+		buf := val.(*buffer)
+		buf.touch()
+		return buf, nil
+	}
+
+	rwMu.Lock()
+	if val, ok := bufs.Load(key); ok {
+		rwMu.Unlock()
+		buf := val.(*buffer)
+		buf.touch()
+		return buf, nil
+	}
 
-	val, ok := bufs.Load(key)
-	if !ok {
-		// buf is new here, we need to create it now
-		buf, err = newBuffer(ctx, key, gOpt.Step)
-		if err != nil {
-			return 0, pkg.ErrInternal
-		}
-		bufs.Store(key, buf)
-	} else {
-		buf, ok = val.(*buffer)
-		if !ok {
-			return 0, pkg.ErrInternal.Message("segment buffer type mismatch")
+	if call, ok := creating[key]; ok {
+		// buf is being created by others, wait for the result
+		rwMu.Unlock()
+		select {
+		case <-call.done:
+			return call.buf, call.err
+		case <-ctx.Done():
+			return nil, pkg.ErrInternal.Message(ctx.Err().Error())
 		}
 	}
 
-	id, err := buf.getId(ctx)
+	// buf is new here, we need to create it now
+	call := &bufCall{done: make(chan struct{})}
+	creating[key] = call
+	rwMu.Unlock()
+
+	call.buf, call.err = createBuffer(ctx, key, gOpt.Step)
+
+	rwMu.Lock()
+	if call.err == nil {
+		storeBuffer(key, call.buf)
+	}
+	delete(creating, key)
+	rwMu.Unlock()
+	close(call.done)
+
+	return call.buf, call.err
+}
+
+func createBuffer(ctx context.Context, key string, step uint32) (*buffer, error) {
+	if err := checkKey(ctx, key); err != nil {
+		return nil, err
+	}
+
+	buf, err := newBuffer(ctx, key, step, store)
 	if err != nil {
-		return 0, pkg.ErrInternal
+		log.Printf("new buffer for key %s err: %v\n", key, err)
+		return nil, keyErr(err)
 	}
 
-	return id, nil
+	return buf, nil
+}
+
+// checkKeyName rejects keys which can not be requested directly, period rows are only dispensed by sequences
+func checkKeyName(key string) error {
+	if len(key) == 0 {
+		return pkg.ErrInvalidArgs.Message("key is empty")
+	}
+	if isPeriodKey(key) {
+		return pkg.ErrInvalidArgs.Message(fmt.Sprintf("key %s is a period row of sequence", key))
+	}
+	return nil
 }
 
+// checkKey makes sure key exists in store unless keys are auto created, and that key is neither disabled
+// nor resets every period
+func checkKey(ctx context.Context, key string) error {
+	alloc, err := store.QueryByKey(ctx, key)
+	if err != nil {
+		if err == dao.ErrKeyNotFound {
+			if autoCreate {
+				return nil
+			}
+			return dao.ErrKeyNotFound.Message(fmt.Sprintf("key %s is not found", key))
+		}
+		return pkg.ErrInternal
+	}
+
+	if alloc.Disabled {
+		return dao.ErrKeyDisabled.Message(fmt.Sprintf("key %s is disabled", key))
+	}
+	if alloc.Period != dao.ResetNone {
+		return ErrKeyPeriodic.Message(fmt.Sprintf("key %s resets every period, take its ids by NextSeq", key))
+	}
+
+	return nil
+}
+
+func errLeaseObfuscated(key string) error {
+	return pkg.ErrInvalidArgs.Message(fmt.Sprintf("key %s is obfuscated and can not be leased", key))
+}
+
+// keyErr passes through errors about the state of key, other errors are hidden as internal error
+func keyErr(err error) error {
+	for _, target := range []error{dao.ErrKeyNotFound, dao.ErrKeyDisabled, dao.ErrKeyGapless, dao.ErrKeyExhausted} {
+		if errors.Is(err, target) {
+			return err
+		}
+	}
+	return pkg.ErrInternal
+}
+
+// Close stops dispensing ids and shuts down all the buffers, the store should be closed by its owner
 func Close() {
 	closed.Store(true)
-	dao.CloseDB()
+	stopJanitor()
+	cleaners.Wait()
+
+	evictAll()
 }
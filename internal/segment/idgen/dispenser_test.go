@@ -0,0 +1,175 @@
+package idgen
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ryanreadbooks/folium/internal/pkg"
+	"github.com/ryanreadbooks/folium/internal/pkg/misc"
+	"github.com/ryanreadbooks/folium/internal/segment/dao"
+	"github.com/stretchr/testify/assert"
+)
+
+// countTakes counts the calls of TakeIdForKey, each call takes at least d
+func countTakes(calls *atomic.Int32, d time.Duration, err error) func(context.Context, string) error {
+	return func(ctx context.Context, op string) error {
+		if op != "TakeIdForKey" {
+			return nil
+		}
+		calls.Add(1)
+		time.Sleep(d)
+		return err
+	}
+}
+
+func TestGetNext_concurrentNewKey(t *testing.T) {
+	defer clean()
+
+	ms := memStore(t)
+	var calls atomic.Int32
+	ms.SetHook(countTakes(&calls, time.Millisecond*50, nil))
+
+	num := 100
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	ids := make([]uint64, 0, num)
+	for i := 0; i < num; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			id, err := GetNext(ctx, "biz-sf-new")
+			assert.Nil(t, err)
+			lock.Lock()
+			ids = append(ids, id)
+			lock.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	// exactly one segment is consumed
+	assert.EqualValues(t, 1, calls.Load())
+	alloc, err := testStore.QueryByKey(ctx, "biz-sf-new")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1001, alloc.CurId)
+	assert.False(t, misc.HasDupElems(ids))
+	for _, id := range ids {
+		assert.Less(t, id, uint64(1001))
+	}
+}
+
+func TestGetNext_concurrentNewKeyErr(t *testing.T) {
+	defer clean()
+
+	ms := memStore(t)
+	var calls atomic.Int32
+	ms.SetHook(countTakes(&calls, time.Millisecond*50, errors.New("db down")))
+
+	num := 100
+	var wg sync.WaitGroup
+	var errs atomic.Int32
+	for i := 0; i < num; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := GetNext(ctx, "biz-sf-err")
+			if err != nil {
+				errs.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// every waiter gets the error of the only fetch
+	assert.EqualValues(t, 1, calls.Load())
+	assert.EqualValues(t, num, errs.Load())
+
+	// key is created once db is back
+	ms.SetHook(nil)
+	id, err := GetNext(ctx, "biz-sf-err")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1, id)
+}
+
+func TestGetNext_strict(t *testing.T) {
+	defer clean()
+
+	autoCreate = false
+	defer func() { autoCreate = true }()
+
+	_, err := GetNext(ctx, "biz-strict")
+	assert.Equal(t, dao.ErrKeyNotFound.Code, err.(*pkg.Err).Code)
+	_, err = GetNextBatch(ctx, "biz-strict", 10)
+	assert.Equal(t, dao.ErrKeyNotFound.Code, err.(*pkg.Err).Code)
+	_, err = LeaseRange(ctx, "biz-strict", 10, "test")
+	assert.Equal(t, dao.ErrKeyNotFound.Code, err.(*pkg.Err).Code)
+
+	// no row is created for unknown key
+	_, err = testStore.QueryByKey(ctx, "biz-strict")
+	assert.Equal(t, dao.ErrKeyNotFound, err)
+
+	err = testStore.CreateUpdate(ctx, &dao.Alloc{Key: "biz-strict", CurId: 100, Step: 10})
+	assert.Nil(t, err)
+	id, err := GetNext(ctx, "biz-strict")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 100, id)
+}
+
+func TestLeaseRange_keepStep(t *testing.T) {
+	defer clean()
+
+	_, err := CreateKey(ctx, &dao.Alloc{Key: "biz-lease", Step: 100})
+	assert.Nil(t, err)
+
+	res, err := LeaseRange(ctx, "biz-lease", 10000, "test")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1, res.Begin)
+	assert.EqualValues(t, 10001, res.End)
+
+	alloc, err := testStore.QueryByKey(ctx, "biz-lease")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 100, alloc.Step)
+
+	// segments after the lease keep the step of key
+	id, err := GetNext(ctx, "biz-lease")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 10001, id)
+	alloc, err = testStore.QueryByKey(ctx, "biz-lease")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 100, alloc.Step)
+}
+
+func TestGetNext_exhausted(t *testing.T) {
+	defer clean()
+
+	_, err := CreateKey(ctx, &dao.Alloc{Key: "biz-max", Step: 100, MinId: 1, MaxId: 150})
+	assert.Nil(t, err)
+
+	ids, err := GetNextBatch(ctx, "biz-max", 150)
+	assert.Nil(t, err)
+	assert.Len(t, ids, 150)
+	assert.EqualValues(t, 150, ids[len(ids)-1])
+
+	_, err = GetNext(ctx, "biz-max")
+	assert.Equal(t, dao.ErrKeyExhausted.Code, err.(*pkg.Err).Code)
+	_, err = LeaseRange(ctx, "biz-max", 10, "test")
+	assert.Equal(t, dao.ErrKeyExhausted.Code, err.(*pkg.Err).Code)
+}
+
+func TestGetNext_cycle(t *testing.T) {
+	defer clean()
+
+	_, err := CreateKey(ctx, &dao.Alloc{Key: "biz-cycle", Step: 100, MinId: 1, MaxId: 150, Exhaust: dao.ExhaustCycle})
+	assert.Nil(t, err)
+
+	for round := 0; round < 3; round++ {
+		for i := 1; i <= 150; i++ {
+			id, err := GetNext(ctx, "biz-cycle")
+			assert.Nil(t, err)
+			assert.EqualValues(t, i, id)
+		}
+	}
+}
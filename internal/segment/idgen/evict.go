@@ -0,0 +1,137 @@
+package idgen
+
+import (
+	"log"
+	"sort"
+	"time"
+)
+
+// buffers of idle keys are evicted so that keys requested once do not stay in memory forever
+
+const (
+	defaultIdleTTL    = time.Minute * 30
+	defaultMaxBuffers = 10000
+)
+
+var (
+	idleTTL    = defaultIdleTTL
+	maxBuffers = defaultMaxBuffers
+	resident   int // number of buffers in bufs, guarded by rwMu
+
+	janitorCloseCh chan struct{}
+	janitorDoneCh  chan struct{}
+)
+
+// storeBuffer makes buf resident, least recently used buffers are evicted if there are too many,
+// it should be called with rwMu held
+func storeBuffer(key string, buf *buffer) {
+	if maxBuffers > 0 && resident >= maxBuffers {
+		// evict a tenth at once so that evicting is not done for every new key
+		evictLRU(resident - maxBuffers + max(maxBuffers/10, 1))
+	}
+
+	buf.idleSince = time.Now()
+	bufs.Store(key, buf)
+	resident++
+}
+
+// evictBuffer drops buf of key and shuts it down, it should be called with rwMu held
+func evictBuffer(key string, buf *buffer) {
+	if !bufs.CompareAndDelete(key, buf) {
+		return
+	}
+
+	resident--
+	buf.close()
+	log.Printf("buffer %s is evicted, idle since %v\n", key, buf.idleSince)
+}
+
+// evictAll drops all the buffers and waits for their workers to exit
+func evictAll() {
+	var evicted []*buffer
+	rwMu.Lock()
+	bufs.Range(func(key, value any) bool {
+		buf := value.(*buffer)
+		evictBuffer(key.(string), buf)
+		evicted = append(evicted, buf)
+		return true
+	})
+	rwMu.Unlock()
+
+	for _, buf := range evicted {
+		<-buf.doneCh
+	}
+}
+
+// evictLRU evicts n buffers which are idle for the longest time, it should be called with rwMu held
+func evictLRU(n int) {
+	type entry struct {
+		key string
+		buf *buffer
+	}
+
+	now := time.Now()
+	entries := make([]entry, 0, resident)
+	bufs.Range(func(key, value any) bool {
+		buf := value.(*buffer)
+		if buf.touched.Swap(false) {
+			buf.idleSince = now
+		}
+		entries = append(entries, entry{key: key.(string), buf: buf})
+		return true
+	})
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].buf.idleSince.Before(entries[j].buf.idleSince)
+	})
+	for i := 0; i < n && i < len(entries); i++ {
+		evictBuffer(entries[i].key, entries[i].buf)
+	}
+}
+
+// sweep evicts buffers which are idle for longer than idleTTL
+func sweep(now time.Time) {
+	rwMu.Lock()
+	defer rwMu.Unlock()
+
+	bufs.Range(func(key, value any) bool {
+		buf := value.(*buffer)
+		if buf.touched.Swap(false) {
+			buf.idleSince = now
+		} else if now.Sub(buf.idleSince) > idleTTL {
+			evictBuffer(key.(string), buf)
+		}
+		return true
+	})
+}
+
+func startJanitor() {
+	if idleTTL <= 0 || janitorCloseCh != nil {
+		return
+	}
+
+	janitorCloseCh = make(chan struct{})
+	janitorDoneCh = make(chan struct{})
+	go func(closeCh, doneCh chan struct{}) {
+		defer close(doneCh)
+
+		ticker := time.NewTicker(min(idleTTL/2, time.Minute))
+		defer ticker.Stop()
+		for {
+			select {
+			case now := <-ticker.C:
+				sweep(now)
+			case <-closeCh:
+				return
+			}
+		}
+	}(janitorCloseCh, janitorDoneCh)
+}
+
+func stopJanitor() {
+	if janitorCloseCh != nil {
+		close(janitorCloseCh)
+		<-janitorDoneCh
+		janitorCloseCh = nil
+	}
+}
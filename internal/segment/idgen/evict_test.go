@@ -0,0 +1,76 @@
+package idgen
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func loadedBuffer(t *testing.T, key string) *buffer {
+	_, err := GetNext(ctx, key)
+	assert.Nil(t, err)
+	val, ok := bufs.Load(key)
+	assert.True(t, ok)
+	return val.(*buffer)
+}
+
+func TestEvict_idle(t *testing.T) {
+	defer clean()
+
+	oldTTL := idleTTL
+	idleTTL = time.Minute
+	defer func() { idleTTL = oldTTL }()
+
+	idle := loadedBuffer(t, "biz-idle")
+	busy := loadedBuffer(t, "biz-busy")
+
+	// busy key is requested within ttl
+	now := time.Now()
+	sweep(now)
+	_, err := GetNext(ctx, "biz-busy")
+	assert.Nil(t, err)
+	sweep(now.Add(time.Minute * 2))
+
+	_, ok := bufs.Load("biz-idle")
+	assert.False(t, ok)
+	_, ok = bufs.Load("biz-busy")
+	assert.True(t, ok)
+
+	// evicted buffer is shut down
+	assert.NotNil(t, idle.ctx.Err())
+	select {
+	case <-idle.closeCh:
+	default:
+		t.Fatal("evicted buffer is not closed")
+	}
+	assert.Nil(t, busy.ctx.Err())
+
+	// key is loaded again with a new segment
+	id, err := GetNext(ctx, "biz-idle")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1001, id)
+}
+
+func TestEvict_maxBuffers(t *testing.T) {
+	defer clean()
+
+	oldMax := maxBuffers
+	maxBuffers = 10
+	defer func() { maxBuffers = oldMax }()
+
+	for i := 0; i < 10; i++ {
+		loadedBuffer(t, fmt.Sprintf("biz-%d", i))
+		time.Sleep(time.Millisecond)
+	}
+	assert.Equal(t, 10, resident)
+
+	// the least recently used one is evicted
+	loadedBuffer(t, "biz-new")
+	assert.Equal(t, 10, resident)
+	_, ok := bufs.Load("biz-0")
+	assert.False(t, ok)
+	_, ok = bufs.Load("biz-1")
+	assert.True(t, ok)
+}
@@ -0,0 +1,275 @@
+package idgen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ryanreadbooks/folium/internal/pkg"
+	"github.com/ryanreadbooks/folium/internal/segment/dao"
+)
+
+// ids of a key can be rendered with the format template of key, a template is literal text with placeholders:
+//
+//	{seq} or {seq:N}  the id, or the sequence number for keys with reset period, zero padded to at least N digits
+//	{date:PATTERN}    the current date in the time zone of key, or the period for keys with reset period,
+//	                  PATTERN takes yyyy, yy, MM, dd, HH, mm and ss, other non letter characters are kept as is
+//	{check}           the luhn check digit of all digits rendered before it
+//
+// for example, ORD-{date:yyyyMMdd}-{seq:6}{check} renders ORD-20261008-0000427 for id 42 on 2026-10-08
+
+const (
+	maxFormatLen = 128
+	maxSeqWidth  = 20 // digits of max uint64
+)
+
+type fmtKind uint8
+
+const (
+	fmtLiteral fmtKind = iota
+	fmtSeq
+	fmtDate
+	fmtCheck
+)
+
+type fmtPart struct {
+	kind  fmtKind
+	text  string   // literal text
+	width int      // zero padded width of seq
+	date  []string // tokens of date pattern
+}
+
+// idFormat is a parsed format template
+type idFormat struct {
+	parts []fmtPart
+}
+
+var dateTokens = []string{"yyyy", "yy", "MM", "dd", "HH", "mm", "ss"}
+
+func parseDate(pattern string) ([]string, error) {
+	var tokens []string
+	for len(pattern) > 0 {
+		matched := false
+		for _, tk := range dateTokens {
+			if strings.HasPrefix(pattern, tk) {
+				tokens = append(tokens, tk)
+				pattern = pattern[len(tk):]
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+
+		c := pattern[0]
+		if c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' {
+			return nil, fmt.Errorf("unknown date token at %q", pattern)
+		}
+		// separators are kept as single character tokens
+		tokens = append(tokens, pattern[:1])
+		pattern = pattern[1:]
+	}
+
+	if len(tokens) == 0 {
+		return nil, errors.New("date pattern is empty")
+	}
+	return tokens, nil
+}
+
+func parsePlaceholder(ph string) (fmtPart, error) {
+	name, arg, hasArg := strings.Cut(ph, ":")
+	switch name {
+	case "seq":
+		part := fmtPart{kind: fmtSeq}
+		if hasArg {
+			width, err := strconv.Atoi(arg)
+			if err != nil || width < 1 || width > maxSeqWidth {
+				return part, fmt.Errorf("seq width should be in [1, %d]", maxSeqWidth)
+			}
+			part.width = width
+		}
+		return part, nil
+	case "date":
+		tokens, err := parseDate(arg)
+		if err != nil {
+			return fmtPart{}, err
+		}
+		return fmtPart{kind: fmtDate, date: tokens}, nil
+	case "check":
+		if hasArg {
+			return fmtPart{}, errors.New("check takes no argument")
+		}
+		return fmtPart{kind: fmtCheck}, nil
+	}
+
+	return fmtPart{}, fmt.Errorf("unknown placeholder {%s}", ph)
+}
+
+// parseFormat parses template, it should contain exactly one {seq}
+func parseFormat(template string) (*idFormat, error) {
+	if len(template) == 0 || len(template) > maxFormatLen {
+		return nil, fmt.Errorf("format length should be in [1, %d]", maxFormatLen)
+	}
+
+	var (
+		f    = &idFormat{}
+		seqs int
+	)
+	for rest := template; len(rest) > 0; {
+		open := strings.IndexAny(rest, "{}")
+		if open < 0 {
+			f.parts = append(f.parts, fmtPart{kind: fmtLiteral, text: rest})
+			break
+		}
+		if rest[open] == '}' {
+			return nil, errors.New("unexpected }")
+		}
+		if open > 0 {
+			f.parts = append(f.parts, fmtPart{kind: fmtLiteral, text: rest[:open]})
+		}
+
+		end := strings.IndexAny(rest[open+1:], "{}")
+		if end < 0 || rest[open+1+end] != '}' {
+			return nil, errors.New("unclosed {")
+		}
+		part, err := parsePlaceholder(rest[open+1 : open+1+end])
+		if err != nil {
+			return nil, err
+		}
+		if part.kind == fmtSeq {
+			seqs++
+		}
+		f.parts = append(f.parts, part)
+		rest = rest[open+1+end+1:]
+	}
+
+	if seqs != 1 {
+		return nil, errors.New("format should contain exactly one {seq}")
+	}
+
+	return f, nil
+}
+
+func appendDate(buf []byte, tokens []string, t time.Time) []byte {
+	for _, tk := range tokens {
+		switch tk {
+		case "yyyy":
+			buf = fmt.Appendf(buf, "%04d", t.Year())
+		case "yy":
+			buf = fmt.Appendf(buf, "%02d", t.Year()%100)
+		case "MM":
+			buf = fmt.Appendf(buf, "%02d", int(t.Month()))
+		case "dd":
+			buf = fmt.Appendf(buf, "%02d", t.Day())
+		case "HH":
+			buf = fmt.Appendf(buf, "%02d", t.Hour())
+		case "mm":
+			buf = fmt.Appendf(buf, "%02d", t.Minute())
+		case "ss":
+			buf = fmt.Appendf(buf, "%02d", t.Second())
+		default:
+			buf = append(buf, tk...)
+		}
+	}
+	return buf
+}
+
+// luhnDigit returns the luhn check digit of digits in s, other characters are skipped
+func luhnDigit(s []byte) byte {
+	var (
+		sum    int
+		double = true // the rightmost digit is doubled as the check digit goes after it
+	)
+	for i := len(s) - 1; i >= 0; i-- {
+		if s[i] < '0' || s[i] > '9' {
+			continue
+		}
+		d := int(s[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// render renders seq taken at t with f
+func (f *idFormat) render(seq uint64, t time.Time) string {
+	buf := make([]byte, 0, 32)
+	for _, part := range f.parts {
+		switch part.kind {
+		case fmtLiteral:
+			buf = append(buf, part.text...)
+		case fmtSeq:
+			buf = fmt.Appendf(buf, "%0*d", part.width, seq)
+		case fmtDate:
+			buf = appendDate(buf, part.date, t)
+		case fmtCheck:
+			buf = append(buf, luhnDigit(buf))
+		}
+	}
+	return string(buf)
+}
+
+func checkFormat(format string) error {
+	if format == "" {
+		return nil
+	}
+	if _, err := parseFormat(format); err != nil {
+		return pkg.ErrInvalidArgs.Message(fmt.Sprintf("format %s is invalid: %v", format, err))
+	}
+	return nil
+}
+
+// GetNextFormatted returns the next id of key rendered with the format of key and the raw id,
+// the raw id is the sequence number in the current period for keys with reset period
+func GetNextFormatted(ctx context.Context, key string) (string, uint64, error) {
+	if closed.Load() {
+		return "", 0, ErrClosed
+	}
+
+	if len(key) == 0 {
+		return "", 0, pkg.ErrInvalidArgs.Message("key is empty")
+	}
+
+	conf, err := getKeyConf(ctx, key)
+	if err != nil {
+		return "", 0, err
+	}
+
+	if conf.format == nil {
+		return "", 0, pkg.ErrInvalidArgs.Message(fmt.Sprintf("key %s has no format", key))
+	}
+
+	if conf.alloc.Period != dao.ResetNone {
+		period, seq, err := nextSeq(ctx, key, conf)
+		if err != nil {
+			return "", 0, err
+		}
+		// the date is the beginning of period, so that it always agrees with the sequence
+		t, err := time.ParseInLocation(periodLayout(conf.alloc.Period), period, conf.loc)
+		if err != nil {
+			return "", 0, pkg.ErrInternal
+		}
+		return conf.format.render(seq, t), seq, nil
+	}
+
+	if conf.alloc.Disabled {
+		return "", 0, dao.ErrKeyDisabled.Message(fmt.Sprintf("key %s is disabled", key))
+	}
+
+	id, err := GetNext(ctx, key)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return conf.format.render(id, seqNow().In(conf.loc)), id, nil
+}
@@ -0,0 +1,91 @@
+package idgen
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ryanreadbooks/folium/internal/pkg"
+	"github.com/ryanreadbooks/folium/internal/segment/dao"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFormat(t *testing.T) {
+	at := time.Date(2026, 10, 8, 9, 5, 3, 0, time.UTC)
+	cases := []struct {
+		format string
+		seq    uint64
+		want   string
+	}{
+		{"{seq}", 42, "42"},
+		{"ORD{seq:8}", 42, "ORD00000042"},
+		{"ORD{seq:2}", 12345, "ORD12345"},
+		{"{date:yyyy-MM-dd HH:mm:ss}/{seq}", 7, "2026-10-08 09:05:03/7"},
+		{"{date:yyMM}{seq:4}", 7, "26100007"},
+		{"{seq}{check}", 7992739871, "79927398713"},
+		{"ORD-{date:yyyyMMdd}-{seq:6}{check}", 42, "ORD-20261008-0000427"},
+	}
+	for _, c := range cases {
+		f, err := parseFormat(c.format)
+		assert.Nil(t, err, c.format)
+		assert.Equal(t, c.want, f.render(c.seq, at), c.format)
+	}
+
+	for _, bad := range []string{"", "ORD", "{seq}{seq}", "{seq", "seq}", "{seq:0}", "{seq:21}",
+		"{id}", "{date:yyyyQQ}{seq}", "{date:}{seq}", "{check:1}{seq}"} {
+		_, err := parseFormat(bad)
+		assert.NotNil(t, err, bad)
+	}
+}
+
+func TestGetNextFormatted(t *testing.T) {
+	defer clean()
+
+	now := time.Date(2026, 10, 18, 20, 0, 0, 0, time.UTC)
+	seqNow = func() time.Time { return now }
+	defer func() { seqNow = time.Now }()
+
+	_, err := CreateKey(ctx, &dao.Alloc{Key: "biz-order", Step: 100, Format: "{seq:"})
+	assert.Equal(t, pkg.ErrInvalidArgs.Code, err.(*pkg.Err).Code)
+
+	_, err = CreateKey(ctx, &dao.Alloc{Key: "biz-order", CurId: 42, Step: 100,
+		Format: "ORD-{date:yyyyMMdd}-{seq:6}", TimeZone: "Asia/Shanghai"})
+	assert.Nil(t, err)
+
+	text, id, err := GetNextFormatted(ctx, "biz-order")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 42, id)
+	// the date is in the time zone of key
+	assert.Equal(t, "ORD-20261019-000042", text)
+
+	err = UpdateFormat(ctx, "biz-order", "O{seq}{check}")
+	assert.Nil(t, err)
+	text, id, err = GetNextFormatted(ctx, "biz-order")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 43, id)
+	assert.Equal(t, "O430", text)
+
+	err = UpdateFormat(ctx, "biz-order", "")
+	assert.Nil(t, err)
+	_, _, err = GetNextFormatted(ctx, "biz-order")
+	assert.Equal(t, pkg.ErrInvalidArgs.Code, err.(*pkg.Err).Code)
+	err = UpdateFormat(ctx, "biz-order", "{date:yyyy}")
+	assert.Equal(t, pkg.ErrInvalidArgs.Code, err.(*pkg.Err).Code)
+}
+
+func TestGetNextFormatted_period(t *testing.T) {
+	defer clean()
+
+	now := time.Date(2026, 10, 18, 23, 59, 59, 0, time.UTC)
+	seqNow = func() time.Time { return now }
+	defer func() { seqNow = time.Now }()
+
+	_, err := CreateKey(ctx, &dao.Alloc{Key: "biz-invoice", Step: 10, Period: dao.ResetDaily,
+		Format: "INV{date:yyyyMMddHHmm}{seq:4}"})
+	assert.Nil(t, err)
+
+	text, seq, err := GetNextFormatted(ctx, "biz-invoice")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1, seq)
+	// the date is the beginning of period
+	assert.Equal(t, "INV2026101800000001", text)
+}
@@ -0,0 +1,121 @@
+package idgen
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/ryanreadbooks/folium/internal/pkg"
+	"github.com/ryanreadbooks/folium/internal/segment/dao"
+	"google.golang.org/grpc/codes"
+)
+
+// numbers of gapless keys bypass the buffers, every number is reserved in store one by one
+// and it is either confirmed or released by its holder, numbers released or never confirmed
+// are reserved again before any new number so that no number is skipped
+
+const (
+	DefaultReserveTTL = time.Minute
+	MaxReserveTTL     = time.Hour
+)
+
+var (
+	ErrGaplessUnsupported = pkg.NewErr(int(codes.Unimplemented), "store does not support gapless keys")
+)
+
+func gaplessStore() (dao.GaplessStore, error) {
+	gs, ok := store.(dao.GaplessStore)
+	if !ok {
+		return nil, ErrGaplessUnsupported
+	}
+	return gs, nil
+}
+
+func checkGapless(alloc *dao.Alloc) error {
+	if !alloc.Gapless {
+		return nil
+	}
+	if alloc.Period != dao.ResetNone || alloc.Format != "" || alloc.Seed != 0 || alloc.Exhaust != dao.ExhaustError {
+		return pkg.ErrInvalidArgs.Message("gapless key can not have reset period, format, obfuscation or cycle exhaust policy")
+	}
+	return nil
+}
+
+func newToken() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", pkg.ErrInternal.Message(fmt.Sprintf("read random token: %v", err))
+	}
+	return hex.EncodeToString(b[:]), nil
+}
+
+// ReserveNum reserves the next number of gapless key for ttl, zero ttl means DefaultReserveTTL,
+// the returned token is required to confirm or release the number before it expires
+func ReserveNum(ctx context.Context, key string, ttl time.Duration) (*dao.Reservation, error) {
+	if closed.Load() {
+		return nil, ErrClosed
+	}
+
+	if len(key) == 0 {
+		return nil, pkg.ErrInvalidArgs.Message("key is empty")
+	}
+
+	if ttl == 0 {
+		ttl = DefaultReserveTTL
+	}
+	if ttl < time.Millisecond || ttl > MaxReserveTTL {
+		return nil, pkg.ErrInvalidArgs.Message(fmt.Sprintf("ttl should be in [1ms, %s]", MaxReserveTTL))
+	}
+
+	gs, err := gaplessStore()
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return nil, err
+	}
+
+	return gs.ReserveNum(ctx, key, token, ttl)
+}
+
+// ConfirmNum makes num reserved with token permanent, dao.ErrReservationNotFound is returned
+// if num is not reserved with token or the reservation expired
+func ConfirmNum(ctx context.Context, key string, num uint64, token string) error {
+	if closed.Load() {
+		return ErrClosed
+	}
+
+	if len(key) == 0 || len(token) == 0 {
+		return pkg.ErrInvalidArgs.Message("key or token is empty")
+	}
+
+	gs, err := gaplessStore()
+	if err != nil {
+		return err
+	}
+
+	return gs.ConfirmNum(ctx, key, num, token)
+}
+
+// ReleaseNum gives num reserved with token back so that it is reserved again before any new number,
+// dao.ErrReservationNotFound is returned if num is not reserved with token or the reservation expired
+func ReleaseNum(ctx context.Context, key string, num uint64, token string) error {
+	if closed.Load() {
+		return ErrClosed
+	}
+
+	if len(key) == 0 || len(token) == 0 {
+		return pkg.ErrInvalidArgs.Message("key or token is empty")
+	}
+
+	gs, err := gaplessStore()
+	if err != nil {
+		return err
+	}
+
+	return gs.ReleaseNum(ctx, key, num, token)
+}
@@ -0,0 +1,68 @@
+package idgen
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ryanreadbooks/folium/internal/pkg"
+	"github.com/ryanreadbooks/folium/internal/segment/dao"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReserveNum(t *testing.T) {
+	defer clean()
+
+	alloc, err := CreateKey(ctx, &dao.Alloc{Key: "biz-invoice", Step: 100, Gapless: true})
+	assert.Nil(t, err)
+	assert.True(t, alloc.Gapless)
+
+	r1, err := ReserveNum(ctx, "biz-invoice", 0)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1, r1.Num)
+	assert.NotEmpty(t, r1.Token)
+	r2, err := ReserveNum(ctx, "biz-invoice", time.Second)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 2, r2.Num)
+	assert.NotEqual(t, r1.Token, r2.Token)
+
+	assert.Nil(t, ConfirmNum(ctx, "biz-invoice", r2.Num, r2.Token))
+	assert.Nil(t, ReleaseNum(ctx, "biz-invoice", r1.Num, r1.Token))
+	err = ReleaseNum(ctx, "biz-invoice", r1.Num, r1.Token)
+	assert.Equal(t, dao.ErrReservationNotFound.Code, err.(*pkg.Err).Code)
+
+	// released number fills the gap
+	r3, err := ReserveNum(ctx, "biz-invoice", 0)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1, r3.Num)
+	assert.Nil(t, ConfirmNum(ctx, "biz-invoice", r3.Num, r3.Token))
+
+	// gapless key never takes segments
+	_, err = GetNext(ctx, "biz-invoice")
+	assert.Equal(t, dao.ErrKeyGapless, err)
+	_, err = JumpAhead(ctx, "biz-invoice", 100)
+	assert.Equal(t, dao.ErrKeyGapless.Code, err.(*pkg.Err).Code)
+
+	_, err = ReserveNum(ctx, "biz-invoice", MaxReserveTTL+1)
+	assert.Equal(t, pkg.ErrInvalidArgs.Code, err.(*pkg.Err).Code)
+	err = ConfirmNum(ctx, "biz-invoice", 1, "")
+	assert.Equal(t, pkg.ErrInvalidArgs.Code, err.(*pkg.Err).Code)
+}
+
+func TestCreateKey_gapless(t *testing.T) {
+	defer clean()
+
+	for _, alloc := range []*dao.Alloc{
+		{Key: "biz-bad", Step: 100, Gapless: true, Period: dao.ResetDaily},
+		{Key: "biz-bad", Step: 100, Gapless: true, Format: "INV{seq:6}"},
+		{Key: "biz-bad", Step: 100, Gapless: true, Seed: 1},
+		{Key: "biz-bad", Step: 100, Gapless: true, MaxId: 10, Exhaust: dao.ExhaustCycle},
+	} {
+		_, err := CreateKey(ctx, alloc)
+		assert.Equal(t, pkg.ErrInvalidArgs.Code, err.(*pkg.Err).Code)
+	}
+
+	_, err := CreateKey(ctx, &dao.Alloc{Key: "biz-plain", Step: 100})
+	assert.Nil(t, err)
+	_, err = ReserveNum(ctx, "biz-plain", 0)
+	assert.Equal(t, dao.ErrKeyNotGapless.Code, err.(*pkg.Err).Code)
+}
@@ -0,0 +1,140 @@
+package idgen
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/ryanreadbooks/folium/internal/pkg"
+	"github.com/ryanreadbooks/folium/internal/segment/dao"
+)
+
+// ids of an obfuscated key are permuted by a keyed feistel network over [1, 2^62),
+// so they are still unique but do not reveal how many ids are taken.
+// It hides the volume from the public, it is not meant to be a cipher
+
+const (
+	obfHalfBits = 31
+	obfHalfMask = 1<<obfHalfBits - 1
+	obfRounds   = 8
+
+	// MaxObfuscatedId is the upper bound of raw and obfuscated ids of obfuscated keys, exclusive
+	MaxObfuscatedId uint64 = 1 << (2 * obfHalfBits)
+)
+
+// feistel keeps the round keys derived from the seed of key
+type feistel [obfRounds]uint64
+
+func splitmix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+func newFeistel(seed uint64) *feistel {
+	var f feistel
+	k := seed
+	for i := range f {
+		k = splitmix64(k)
+		f[i] = k
+	}
+	return &f
+}
+
+func (f *feistel) round(half uint64, i int) uint64 {
+	return splitmix64(half^f[i]) & obfHalfMask
+}
+
+func (f *feistel) encrypt(x uint64) uint64 {
+	l, r := x>>obfHalfBits, x&obfHalfMask
+	for i := 0; i < obfRounds; i++ {
+		l, r = r, l^f.round(r, i)
+	}
+	return l<<obfHalfBits | r
+}
+
+func (f *feistel) decrypt(y uint64) uint64 {
+	l, r := y>>obfHalfBits, y&obfHalfMask
+	for i := obfRounds - 1; i >= 0; i-- {
+		l, r = r^f.round(l, i), l
+	}
+	return l<<obfHalfBits | r
+}
+
+// obfuscate maps id in [1, MaxObfuscatedId) to another id in the same range,
+// zero is walked over as it is not a valid id
+func (f *feistel) obfuscate(id uint64) (uint64, error) {
+	if id == 0 || id >= MaxObfuscatedId {
+		return 0, dao.ErrKeyExhausted
+	}
+
+	y := f.encrypt(id)
+	for y == 0 {
+		y = f.encrypt(y)
+	}
+	return y, nil
+}
+
+// deobfuscate is the reverse of obfuscate
+func (f *feistel) deobfuscate(id uint64) (uint64, error) {
+	if id == 0 || id >= MaxObfuscatedId {
+		return 0, fmt.Errorf("id should be in [1, %d)", MaxObfuscatedId)
+	}
+
+	x := f.decrypt(id)
+	for x == 0 {
+		x = f.decrypt(x)
+	}
+	return x, nil
+}
+
+// NewSeed returns a random seed which ids of a new key can be obfuscated with
+func NewSeed() (uint64, error) {
+	var b [8]byte
+	for {
+		if _, err := rand.Read(b[:]); err != nil {
+			return 0, pkg.ErrInternal.Message(fmt.Sprintf("read random seed: %v", err))
+		}
+		// seed fits in signed bigint columns
+		seed := binary.BigEndian.Uint64(b[:]) & math.MaxInt64
+		if seed != 0 {
+			return seed, nil
+		}
+	}
+}
+
+func checkSeed(alloc *dao.Alloc) error {
+	if alloc.Seed == 0 {
+		return nil
+	}
+	if alloc.MaxId >= MaxObfuscatedId {
+		return pkg.ErrInvalidArgs.Message(fmt.Sprintf("max id of obfuscated key should be less than %d", MaxObfuscatedId))
+	}
+	return nil
+}
+
+// DecodeId maps id obfuscated by key back to its raw id
+func DecodeId(ctx context.Context, key string, id uint64) (uint64, error) {
+	if len(key) == 0 {
+		return 0, pkg.ErrInvalidArgs.Message("key is empty")
+	}
+
+	alloc, err := store.QueryByKey(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+
+	if alloc.Seed == 0 {
+		return 0, pkg.ErrInvalidArgs.Message(fmt.Sprintf("key %s is not obfuscated", key))
+	}
+
+	raw, err := newFeistel(alloc.Seed).deobfuscate(id)
+	if err != nil {
+		return 0, pkg.ErrInvalidArgs.Message(err.Error())
+	}
+
+	return raw, nil
+}
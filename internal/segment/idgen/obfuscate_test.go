@@ -0,0 +1,85 @@
+package idgen
+
+import (
+	"testing"
+
+	"github.com/ryanreadbooks/folium/internal/pkg"
+	"github.com/ryanreadbooks/folium/internal/segment/dao"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeistel(t *testing.T) {
+	f := newFeistel(20261018)
+	seen := make(map[uint64]struct{})
+	for id := uint64(1); id <= 100000; id++ {
+		y, err := f.obfuscate(id)
+		assert.Nil(t, err)
+		assert.NotZero(t, y)
+		assert.Less(t, y, MaxObfuscatedId)
+		seen[y] = struct{}{}
+
+		x, err := f.deobfuscate(y)
+		assert.Nil(t, err)
+		assert.Equal(t, id, x)
+	}
+	assert.Len(t, seen, 100000)
+
+	for _, id := range []uint64{MaxObfuscatedId - 1, MaxObfuscatedId / 2} {
+		y, err := f.obfuscate(id)
+		assert.Nil(t, err)
+		x, err := f.deobfuscate(y)
+		assert.Nil(t, err)
+		assert.Equal(t, id, x)
+	}
+
+	_, err := f.obfuscate(MaxObfuscatedId)
+	assert.NotNil(t, err)
+	_, err = f.deobfuscate(0)
+	assert.NotNil(t, err)
+
+	// different seeds permute differently
+	y1, _ := f.obfuscate(1)
+	y2, _ := newFeistel(20261019).obfuscate(1)
+	assert.NotEqual(t, y1, y2)
+}
+
+func TestGetNext_obfuscated(t *testing.T) {
+	defer clean()
+
+	seed, err := NewSeed()
+	assert.Nil(t, err)
+	alloc, err := CreateKey(ctx, &dao.Alloc{Key: "biz-user", Step: 100, Seed: seed})
+	assert.Nil(t, err)
+	assert.Equal(t, MaxObfuscatedId-1, alloc.MaxId)
+
+	id, err := GetNext(ctx, "biz-user")
+	assert.Nil(t, err)
+	raw, err := DecodeId(ctx, "biz-user", id)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1, raw)
+
+	ids, err := GetNextBatch(ctx, "biz-user", 10)
+	assert.Nil(t, err)
+	for i, id := range ids {
+		assert.NotEqual(t, uint64(i+2), id)
+		raw, err := DecodeId(ctx, "biz-user", id)
+		assert.Nil(t, err)
+		assert.EqualValues(t, i+2, raw)
+	}
+
+	_, err = LeaseRange(ctx, "biz-user", 10, "test")
+	assert.Equal(t, pkg.ErrInvalidArgs.Code, err.(*pkg.Err).Code)
+	// rejected lease takes nothing from store
+	alloc, err = testStore.QueryByKey(ctx, "biz-user")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 101, alloc.CurId)
+
+	_, err = CreateKey(ctx, &dao.Alloc{Key: "biz-plain", Step: 100})
+	assert.Nil(t, err)
+	_, err = DecodeId(ctx, "biz-plain", id)
+	assert.Equal(t, pkg.ErrInvalidArgs.Code, err.(*pkg.Err).Code)
+	_, err = DecodeId(ctx, "biz-none", id)
+	assert.Equal(t, dao.ErrKeyNotFound.Code, err.(*pkg.Err).Code)
+	_, err = CreateKey(ctx, &dao.Alloc{Key: "biz-bad", Step: 100, Seed: seed, MaxId: MaxObfuscatedId})
+	assert.Equal(t, pkg.ErrInvalidArgs.Code, err.(*pkg.Err).Code)
+}
@@ -3,70 +3,102 @@ package idgen
 import (
 	"context"
 	"fmt"
-	"sync"
+	"math"
 	"sync/atomic"
 
 	"github.com/ryanreadbooks/folium/internal/segment/dao"
 )
 
+// segment dispenses ids in [begin, max), begin and max never change once the segment is published,
+// cur is advanced atomically so that ids can be taken without lock
 type segment struct {
-	sync.Mutex
-
-	name string
-	key  string
-	cur  uint64
-	max  uint64 // can not reach max
+	key   string
+	begin uint64 // the first id of segment
+	cur   uint64
+	max   uint64 // can not reach max
+	mark  uint64 // watermark is hit when this id is taken
+	store dao.AllocStore
 }
 
 func (s *segment) String() string {
 	if s == nil {
 		return ""
 	}
-	return fmt.Sprintf("name: %s, cur: %d, max: %d", s.name, s.cur, s.max)
+	return fmt.Sprintf("key: %s, begin: %d, cur: %d, max: %d", s.key, s.begin, s.getCur(), s.max)
 }
 
-func newSegment(key string) *segment {
+func newSegment(key string, store dao.AllocStore) *segment {
 	return &segment{
-		key: key,
+		key:   key,
+		store: store,
 	}
 }
 
-// returns the current id and increment current id
-// make sure this is concurrency-safe from the outside
+// returns the current id and increment current id,
+// the returned id is beyond max if segment is used up
 func (s *segment) nextAndIncr() uint64 {
-	old := s.cur
-	s.cur += 1
-	return old
+	return atomic.AddUint64(&s.cur, 1) - 1
 }
 
-// update max id
+// take at most n ids, [from, to) is taken and it is empty if segment is used up
+func (s *segment) take(n uint64) (from, to uint64) {
+	from = atomic.AddUint64(&s.cur, n) - n
+	if from >= s.max {
+		return from, from
+	}
+	return from, min(from+n, s.max)
+}
+
+// update max id, segment should not be published yet
 func (s *segment) update(newCur, newMax uint64) {
-	// make sure this is concurrency-safe from the outside
+	s.begin = newCur
 	s.cur = newCur
 	s.max = newMax
 }
 
+// setMark sets the id at which watermark is hit, segment should not be published yet
+func (s *segment) setMark(watermark float64) {
+	s.mark = s.begin + uint64(math.Ceil(float64(s.max-s.begin)*watermark))
+}
+
+// raise skips the ids below floor, segment is used up if floor is beyond max
+func (s *segment) raise(floor uint64) {
+	to := min(floor, s.max)
+	for {
+		cur := atomic.LoadUint64(&s.cur)
+		if cur >= to || atomic.CompareAndSwapUint64(&s.cur, cur, to) {
+			return
+		}
+	}
+}
+
 // check if current id overflow
 func (s *segment) overflow() bool {
 	// that cur is equals to max is considered as overflow as well
 	return atomic.LoadUint64(&s.cur) >= atomic.LoadUint64(&s.max)
 }
 
+// check if the consumed part of segment reaches watermark, which is measured from the beginning of segment
 func (s *segment) hitMark(watermark float64) bool {
-	curWaterMark := float64(atomic.LoadUint64(&s.cur) / atomic.LoadUint64(&s.max))
+	cur, max := atomic.LoadUint64(&s.cur), atomic.LoadUint64(&s.max)
+	if max <= s.begin {
+		// empty segment
+		return true
+	}
+	curWaterMark := float64(cur-s.begin) / float64(max-s.begin)
 	return curWaterMark >= watermark
 }
 
 // fetch from db and update segment
 // newStep is a option step param which will be the new step for key
-func (s *segment) fetchDB(ctx context.Context, newStep uint32) error {
-	res, err := dao.TakeIdForKey(ctx, s.key, newStep)
+func (s *segment) fetchDB(ctx context.Context, newStep uint32) (*dao.TakeIdResult, error) {
+	res, err := s.store.TakeIdForKey(ctx, s.key, newStep)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	s.update(res.Begin, res.End)
-	return nil
+	return res, nil
 }
 
 func (s *segment) getCur() uint64 {
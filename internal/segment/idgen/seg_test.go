@@ -4,8 +4,10 @@ package idgen
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"math/rand"
+	"os"
 	"sync"
 	"testing"
 	"time"
@@ -15,34 +17,89 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+// store backend under test, in-memory store is used if not set
+const ENV_TEST_STORE = "ENV_TEST_STORE"
+
 func TestMain(m *testing.M) {
-	dao.InitDB()
+	var err error
+	name := os.Getenv(ENV_TEST_STORE)
+	if name == "" || name == dao.StoreMemory {
+		testStore = dao.NewMemoryStore()
+	} else {
+		testStore, err = dao.OpenStore(name)
+	}
+	if err != nil {
+		panic(err)
+	}
+	if err = Init(Config{Store: testStore, AutoCreate: true}); err != nil {
+		panic(err)
+	}
 	m.Run()
-	dao.CloseDB()
+	testStore.Close()
 }
 
 var (
-	ctx = context.Background()
+	ctx       = context.Background()
+	testStore dao.AllocStore
 )
 
+// memStore returns the in-memory store under test, t is skipped if another store is used
+func memStore(t *testing.T) *dao.MemoryStore {
+	s, ok := testStore.(*dao.MemoryStore)
+	if !ok {
+		t.Skipf("store %T can not inject failures", testStore)
+	}
+	return s
+}
+
 func clean() {
-	_, err := dao.GetDB().ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE id>0", dao.TableName))
-	if err != nil {
-		println(err.Error())
+	cleaners.Wait()
+	evictAll()
+	keyConfs.Range(func(key, value any) bool {
+		keyConfs.Delete(key)
+		return true
+	})
+
+	switch s := testStore.(type) {
+	case *dao.MemoryStore:
+		s.Reset()
+	case interface{ GetDB() *sql.DB }:
+		// mysql or postgres store
+		_, err := s.GetDB().ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE id>0", dao.TableName))
+		if err == nil {
+			_, err = s.GetDB().ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE id>0", dao.GaplessTableName))
+		}
+		if err != nil {
+			println(err.Error())
+		}
 	}
 }
 
 func TestSegment_fetchDB(t *testing.T) {
 	defer clean()
 
-	seg := newSegment("biz-test")
-	err := seg.fetchDB(ctx, 0)
+	seg := newSegment("biz-test", testStore)
+	res, err := seg.fetchDB(ctx, 0)
 	assert.Nil(t, err)
+	assert.EqualValues(t, 1000, res.Step)
 	assert.EqualValues(t, 1, seg.cur)
 	assert.EqualValues(t, 1001, seg.max)
 	t.Logf("seg = %+v\n", seg)
 }
 
+func TestSegment_hitMark(t *testing.T) {
+	seg := &segment{key: "biz-test"}
+	seg.update(1001, 2001)
+	assert.False(t, seg.hitMark(0.85))
+
+	seg.cur = 1851
+	assert.True(t, seg.hitMark(0.85))
+	assert.False(t, seg.hitMark(0.9))
+
+	seg.cur = 2001
+	assert.True(t, seg.hitMark(1))
+}
+
 func TestSegment_nextAndIncr(t *testing.T) {
 	seg := &segment{
 		key: "biz-ztest",
@@ -0,0 +1,295 @@
+package idgen
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ryanreadbooks/folium/internal/pkg"
+	"github.com/ryanreadbooks/folium/internal/segment/dao"
+)
+
+// date scoped sequences start over every period, each period of a key is counted in its own alloc row
+// named by periodKey, so periods are dispensed by buffers just like plain keys.
+// periodSep is reserved for period rows, they are hidden from listing and warm up,
+// and rows older than the previous period are deleted in the background once a new period starts
+
+const (
+	periodSep = "#"
+
+	// config of sequence and formatted keys is cached for keyConfTTL, so that changes made on other nodes are picked up
+	keyConfTTL = time.Minute
+
+	cleanPeriodsTimeout = time.Minute
+)
+
+var (
+	keyConfs sync.Map // key -> *keyConf
+	seqNow   = time.Now
+
+	cleanMu  sync.Mutex
+	cleaning = make(map[string]string) // key whose old period rows are being deleted -> the oldest period to keep
+	cleaners sync.WaitGroup
+)
+
+type keyConf struct {
+	alloc    *dao.Alloc
+	loc      *time.Location
+	format   *idFormat // nil if key has no format
+	loadedAt time.Time
+}
+
+// periodLen returns the length of period string of p, zero if p is not supported
+func periodLen(p dao.ResetPeriod) int {
+	switch p {
+	case dao.ResetDaily:
+		return len("20060102")
+	case dao.ResetMonthly:
+		return len("200601")
+	}
+	return 0
+}
+
+func periodLayout(p dao.ResetPeriod) string {
+	if p == dao.ResetMonthly {
+		return "200601"
+	}
+	return "20060102"
+}
+
+// periodOf returns the period which t belongs to, like 20261018 for daily and 202610 for monthly
+func periodOf(p dao.ResetPeriod, t time.Time) string {
+	return t.Format(periodLayout(p))
+}
+
+func periodKey(key, period string) string {
+	return key + periodSep + period
+}
+
+// isPeriodKey reports whether key names a period row, user keys never contain periodSep
+func isPeriodKey(key string) bool {
+	return strings.Contains(key, periodSep)
+}
+
+// prevPeriod returns the period before the one which t belongs to
+func prevPeriod(p dao.ResetPeriod, t time.Time) string {
+	if p == dao.ResetMonthly {
+		return periodOf(p, time.Date(t.Year(), t.Month()-1, 1, 0, 0, 0, 0, t.Location()))
+	}
+	return periodOf(p, t.AddDate(0, 0, -1))
+}
+
+func checkPeriod(alloc *dao.Alloc) error {
+	if alloc.Period == dao.ResetNone {
+		if alloc.TimeZone != "" && alloc.Format == "" {
+			return pkg.ErrInvalidArgs.Message("time zone is only for keys with reset period or format")
+		}
+		if _, err := time.LoadLocation(alloc.TimeZone); err != nil {
+			return pkg.ErrInvalidArgs.Message(fmt.Sprintf("time zone %s is invalid: %v", alloc.TimeZone, err))
+		}
+		return nil
+	}
+
+	n := periodLen(alloc.Period)
+	if n == 0 {
+		return pkg.ErrInvalidArgs.Message(fmt.Sprintf("reset period %d is not supported", alloc.Period))
+	}
+	if len(alloc.Key)+len(periodSep)+n > maxKeyLen {
+		return pkg.ErrInvalidArgs.Message(fmt.Sprintf("key length should be in [1, %d] for reset period", maxKeyLen-len(periodSep)-n))
+	}
+	if _, err := time.LoadLocation(alloc.TimeZone); err != nil {
+		return pkg.ErrInvalidArgs.Message(fmt.Sprintf("time zone %s is invalid: %v", alloc.TimeZone, err))
+	}
+
+	return nil
+}
+
+func getKeyConf(ctx context.Context, key string) (*keyConf, error) {
+	if val, ok := keyConfs.Load(key); ok {
+		conf := val.(*keyConf)
+		if time.Since(conf.loadedAt) < keyConfTTL {
+			return conf, nil
+		}
+	}
+
+	alloc, err := store.QueryByKey(ctx, key)
+	if err != nil {
+		if err == dao.ErrKeyNotFound {
+			return nil, dao.ErrKeyNotFound.Message(fmt.Sprintf("key %s is not found", key))
+		}
+		return nil, pkg.ErrInternal
+	}
+
+	loc, err := time.LoadLocation(alloc.TimeZone)
+	if err != nil {
+		return nil, pkg.ErrInternal.Message(fmt.Sprintf("time zone %s of key %s is invalid: %v", alloc.TimeZone, key, err))
+	}
+
+	conf := &keyConf{alloc: alloc, loc: loc, loadedAt: time.Now()}
+	if alloc.Format != "" {
+		conf.format, err = parseFormat(alloc.Format)
+		if err != nil {
+			return nil, pkg.ErrInternal.Message(fmt.Sprintf("format of key %s is invalid: %v", key, err))
+		}
+	}
+	keyConfs.Store(key, conf)
+	return conf, nil
+}
+
+// ensurePeriodKey creates the alloc row of period key which takes the bounds of its sequence key,
+// it reports whether the row is created by this call
+func ensurePeriodKey(ctx context.Context, alloc *dao.Alloc, pkey string) (bool, error) {
+	err := store.CreateKey(ctx, &dao.Alloc{
+		Key:     pkey,
+		Step:    alloc.Step,
+		MinStep: alloc.MinStep,
+		MaxStep: alloc.MaxStep,
+		MinId:   alloc.MinId,
+		MaxId:   alloc.MaxId,
+		Exhaust: alloc.Exhaust,
+		Seed:    alloc.Seed,
+	})
+	if err == dao.ErrKeyExists {
+		return false, nil
+	}
+	if err != nil {
+		return false, pkg.ErrInternal
+	}
+
+	return true, nil
+}
+
+// cleanPeriods deletes period rows of key older than keep, the previous period is kept
+// so that nodes whose clocks lag behind do not recreate it from scratch
+func cleanPeriods(ctx context.Context, key, keep string) {
+	prefix := key + periodSep
+	after := prefix
+	for {
+		allocs, err := store.QueryPage(ctx, after, defaultPageSize)
+		if err != nil {
+			log.Printf("clean periods of key %s err: %v\n", key, err)
+			return
+		}
+
+		for _, alloc := range allocs {
+			period, ok := strings.CutPrefix(alloc.Key, prefix)
+			if !ok || period >= keep {
+				return
+			}
+			if err = store.DeleteKey(ctx, alloc.Key); err != nil && err != dao.ErrKeyNotFound {
+				log.Printf("delete period key %s err: %v\n", alloc.Key, err)
+				return
+			}
+
+			rwMu.Lock()
+			if val, ok := bufs.Load(alloc.Key); ok {
+				evictBuffer(alloc.Key, val.(*buffer))
+			}
+			rwMu.Unlock()
+		}
+
+		if len(allocs) < defaultPageSize {
+			return
+		}
+		after = allocs[len(allocs)-1].Key
+	}
+}
+
+// cleanPeriodsAsync runs cleanPeriods in the background so that requests do not wait for it,
+// at most one cleaner runs for a key, it cleans again if a newer period starts in the meantime
+func cleanPeriodsAsync(key, keep string) {
+	cleanMu.Lock()
+	cur, running := cleaning[key]
+	if keep > cur {
+		cleaning[key] = keep
+	}
+	cleanMu.Unlock()
+	if running {
+		return
+	}
+
+	cleaners.Add(1)
+	go func() {
+		defer cleaners.Done()
+
+		for done := ""; ; {
+			cleanMu.Lock()
+			keep := cleaning[key]
+			if keep == done {
+				delete(cleaning, key)
+				cleanMu.Unlock()
+				return
+			}
+			cleanMu.Unlock()
+
+			ctx, cancel := context.WithTimeout(context.Background(), cleanPeriodsTimeout)
+			cleanPeriods(ctx, key, keep)
+			cancel()
+			done = keep
+		}
+	}()
+}
+
+// GetNextSeq returns the current period of key and the next sequence number in the period,
+// key should be created with a reset period
+func GetNextSeq(ctx context.Context, key string) (string, uint64, error) {
+	if closed.Load() {
+		return "", 0, ErrClosed
+	}
+
+	if len(key) == 0 {
+		return "", 0, pkg.ErrInvalidArgs.Message("key is empty")
+	}
+
+	conf, err := getKeyConf(ctx, key)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return nextSeq(ctx, key, conf)
+}
+
+// currentPeriod returns the current period of sequence key and the key of its row, the row is created if needed
+func currentPeriod(ctx context.Context, key string, conf *keyConf) (string, string, error) {
+	now := seqNow().In(conf.loc)
+	period := periodOf(conf.alloc.Period, now)
+	pkey := periodKey(key, period)
+	if _, ok := bufs.Load(pkey); !ok {
+		// buffer of a new period, or of a period evicted for idling
+		created, err := ensurePeriodKey(ctx, conf.alloc, pkey)
+		if err != nil {
+			return "", "", err
+		}
+		if created {
+			// only the node which starts the period cleans up
+			cleanPeriodsAsync(key, prevPeriod(conf.alloc.Period, now))
+		}
+	}
+
+	return period, pkey, nil
+}
+
+func nextSeq(ctx context.Context, key string, conf *keyConf) (string, uint64, error) {
+	if conf.alloc.Period == dao.ResetNone {
+		return "", 0, pkg.ErrInvalidArgs.Message(fmt.Sprintf("key %s has no reset period", key))
+	}
+
+	if conf.alloc.Disabled {
+		return "", 0, dao.ErrKeyDisabled.Message(fmt.Sprintf("key %s is disabled", key))
+	}
+
+	period, pkey, err := currentPeriod(ctx, key, conf)
+	if err != nil {
+		return "", 0, err
+	}
+
+	seq, err := getNext(ctx, pkey)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return period, seq, nil
+}
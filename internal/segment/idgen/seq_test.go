@@ -0,0 +1,155 @@
+package idgen
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ryanreadbooks/folium/internal/pkg"
+	"github.com/ryanreadbooks/folium/internal/segment/dao"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetNextSeq(t *testing.T) {
+	defer clean()
+
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	assert.Nil(t, err)
+	now := time.Date(2026, 10, 18, 23, 59, 0, 0, loc)
+	seqNow = func() time.Time { return now }
+	defer func() { seqNow = time.Now }()
+
+	_, _, err = GetNextSeq(ctx, "biz-order")
+	assert.Equal(t, dao.ErrKeyNotFound.Code, err.(*pkg.Err).Code)
+
+	_, err = CreateKey(ctx, &dao.Alloc{Key: "biz-order", Step: 10, MaxId: 999999, Period: dao.ResetDaily, TimeZone: "Asia/Shanghai"})
+	assert.Nil(t, err)
+
+	for i := 1; i <= 25; i++ {
+		period, seq, err := GetNextSeq(ctx, "biz-order")
+		assert.Nil(t, err)
+		assert.Equal(t, "20261018", period)
+		assert.EqualValues(t, i, seq)
+	}
+
+	// the sequence starts over in the next day of the time zone
+	now = now.Add(time.Minute * 2)
+	period, seq, err := GetNextSeq(ctx, "biz-order")
+	assert.Nil(t, err)
+	assert.Equal(t, "20261019", period)
+	assert.EqualValues(t, 1, seq)
+
+	// period rows take the bounds of sequence key
+	alloc, err := testStore.QueryByKey(ctx, "biz-order#20261019")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 999999, alloc.MaxId)
+	assert.Equal(t, dao.ResetNone, alloc.Period)
+
+	err = DisableKey(ctx, "biz-order")
+	assert.Nil(t, err)
+	_, _, err = GetNextSeq(ctx, "biz-order")
+	assert.Equal(t, dao.ErrKeyDisabled.Code, err.(*pkg.Err).Code)
+}
+
+func TestGetNextSeq_monthly(t *testing.T) {
+	defer clean()
+
+	now := time.Date(2026, 10, 31, 23, 0, 0, 0, time.UTC)
+	seqNow = func() time.Time { return now }
+	defer func() { seqNow = time.Now }()
+
+	_, err := CreateKey(ctx, &dao.Alloc{Key: "biz-invoice", Step: 10, Period: dao.ResetMonthly})
+	assert.Nil(t, err)
+
+	period, seq, err := GetNextSeq(ctx, "biz-invoice")
+	assert.Nil(t, err)
+	assert.Equal(t, "202610", period)
+	assert.EqualValues(t, 1, seq)
+
+	now = now.Add(time.Hour)
+	period, seq, err = GetNextSeq(ctx, "biz-invoice")
+	assert.Nil(t, err)
+	assert.Equal(t, "202611", period)
+	assert.EqualValues(t, 1, seq)
+}
+
+func TestGetNextSeq_cleanPeriods(t *testing.T) {
+	defer clean()
+
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	seqNow = func() time.Time { return now }
+	defer func() { seqNow = time.Now }()
+
+	_, err := CreateKey(ctx, &dao.Alloc{Key: "biz-order", Step: 10, Period: dao.ResetDaily})
+	assert.Nil(t, err)
+	_, err = CreateKey(ctx, &dao.Alloc{Key: "biz-order-x", Step: 10, Period: dao.ResetDaily})
+	assert.Nil(t, err)
+
+	for i := 0; i < 3; i++ {
+		_, _, err = GetNextSeq(ctx, "biz-order")
+		assert.Nil(t, err)
+		_, _, err = GetNextSeq(ctx, "biz-order-x")
+		assert.Nil(t, err)
+		now = now.AddDate(0, 0, 1)
+	}
+
+	// rows older than the previous period are deleted in the background, other keys are not touched
+	cleaners.Wait()
+	_, err = testStore.QueryByKey(ctx, "biz-order#20261017")
+	assert.Equal(t, dao.ErrKeyNotFound, err)
+	_, ok := bufs.Load("biz-order#20261017")
+	assert.False(t, ok)
+	for _, key := range []string{"biz-order#20261018", "biz-order#20261019", "biz-order-x#20261018", "biz-order-x#20261019"} {
+		_, err = testStore.QueryByKey(ctx, key)
+		assert.Nil(t, err, key)
+	}
+
+	assert.Equal(t, "202609", prevPeriod(dao.ResetMonthly, time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "20260228", prevPeriod(dao.ResetDaily, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestGetNextSeq_invalid(t *testing.T) {
+	defer clean()
+
+	_, err := CreateKey(ctx, &dao.Alloc{Key: "biz-plain", Step: 10})
+	assert.Nil(t, err)
+	_, _, err = GetNextSeq(ctx, "biz-plain")
+	assert.Equal(t, pkg.ErrInvalidArgs.Code, err.(*pkg.Err).Code)
+
+	_, err = CreateKey(ctx, &dao.Alloc{Key: "biz-bad", Step: 10, Period: dao.ResetDaily, TimeZone: "Mars/Olympus"})
+	assert.Equal(t, pkg.ErrInvalidArgs.Code, err.(*pkg.Err).Code)
+	_, err = CreateKey(ctx, &dao.Alloc{Key: "biz-bad", Step: 10, Period: 9})
+	assert.Equal(t, pkg.ErrInvalidArgs.Code, err.(*pkg.Err).Code)
+	_, err = CreateKey(ctx, &dao.Alloc{Key: "biz-bad", Step: 10, TimeZone: "UTC"})
+	assert.Equal(t, pkg.ErrInvalidArgs.Code, err.(*pkg.Err).Code)
+}
+
+func TestGetNextSeq_plainApis(t *testing.T) {
+	defer clean()
+
+	_, err := CreateKey(ctx, &dao.Alloc{Key: "biz-order", Step: 10, Period: dao.ResetDaily})
+	assert.Nil(t, err)
+	_, seq, err := GetNextSeq(ctx, "biz-order")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1, seq)
+
+	// ids of sequence key are only taken by GetNextSeq
+	_, err = GetNext(ctx, "biz-order")
+	assert.Equal(t, ErrKeyPeriodic.Code, err.(*pkg.Err).Code)
+	_, err = GetNextBatch(ctx, "biz-order", 10)
+	assert.Equal(t, ErrKeyPeriodic.Code, err.(*pkg.Err).Code)
+	_, err = LeaseRange(ctx, "biz-order", 10, "test")
+	assert.Equal(t, ErrKeyPeriodic.Code, err.(*pkg.Err).Code)
+
+	// period rows can not be requested directly
+	pkey := periodKey("biz-order", periodOf(dao.ResetDaily, time.Now()))
+	_, err = GetNext(ctx, pkey)
+	assert.Equal(t, pkg.ErrInvalidArgs.Code, err.(*pkg.Err).Code)
+	_, err = GetNextBatch(ctx, pkey, 10)
+	assert.Equal(t, pkg.ErrInvalidArgs.Code, err.(*pkg.Err).Code)
+	_, err = LeaseRange(ctx, pkey, 10, "test")
+	assert.Equal(t, pkg.ErrInvalidArgs.Code, err.(*pkg.Err).Code)
+
+	_, seq, err = GetNextSeq(ctx, "biz-order")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 2, seq)
+}
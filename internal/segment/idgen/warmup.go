@@ -0,0 +1,124 @@
+package idgen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ryanreadbooks/folium/internal/pkg"
+	"github.com/ryanreadbooks/folium/internal/segment/dao"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	defaultWarmUpConcurrency = 8
+)
+
+var (
+	ready atomic.Bool
+
+	ErrNotReady = pkg.NewErr(int(codes.Unavailable), "segment idgen is warming up")
+)
+
+// Ready reports whether idgen is done warming up and still dispensing ids
+func Ready() bool {
+	return ready.Load() && !closed.Load()
+}
+
+// WarmUp loads buffers of keys with at most concurrency keys at a time, then idgen is marked as ready.
+// All the keys in store except period rows are loaded if keys is empty. Keys which fail to load are loaded on their first request.
+// Gapless and disabled keys are skipped, and the current period is loaded for keys with reset period.
+func WarmUp(ctx context.Context, keys []string, concurrency int) error {
+	defer ready.Store(true)
+
+	start := time.Now()
+	if len(keys) == 0 {
+		all, err := store.QueryAllKeys(ctx)
+		if err != nil {
+			return err
+		}
+		for _, key := range all {
+			// periods other than the current one are never requested
+			if !isPeriodKey(key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	if maxBuffers > 0 && len(keys) > maxBuffers {
+		log.Printf("warm up %d keys out of %d because of max buffers\n", maxBuffers, len(keys))
+		keys = keys[:maxBuffers]
+	}
+
+	if concurrency <= 0 {
+		concurrency = defaultWarmUpConcurrency
+	}
+
+	var (
+		wg     sync.WaitGroup
+		failed atomic.Int32
+		sem    = make(chan struct{}, concurrency)
+	)
+	for _, key := range keys {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return fmt.Errorf("warm up is interrupted: %v", ctx.Err())
+		}
+
+		wg.Add(1)
+		go func(key string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			if err := warmUpKey(ctx, key); err != nil {
+				log.Printf("warm up key %s err: %v\n", key, err)
+				failed.Add(1)
+			}
+		}(key)
+	}
+	wg.Wait()
+
+	log.Printf("warm up %d keys in %v, %d failed\n", len(keys), time.Since(start), failed.Load())
+	if n := failed.Load(); n != 0 {
+		return fmt.Errorf("%d of %d keys failed to warm up", n, len(keys))
+	}
+
+	return nil
+}
+
+// warmUpKey loads the buffer of key, or the buffer of its current period if key resets every period
+func warmUpKey(ctx context.Context, key string) error {
+	conf, err := getKeyConf(ctx, key)
+	if err != nil {
+		var pkgErr *pkg.Err
+		if autoCreate && errors.As(err, &pkgErr) && pkgErr.Code == dao.ErrKeyNotFound.Code {
+			// key is created by loading it just like its first request
+			_, err = getBuffer(ctx, key)
+		}
+		return err
+	}
+
+	switch {
+	case conf.alloc.Gapless, conf.alloc.Disabled:
+		// no buffer is ever loaded for them
+		return nil
+	case conf.alloc.Period != dao.ResetNone:
+		_, pkey, err := currentPeriod(ctx, key, conf)
+		if err != nil {
+			return err
+		}
+		_, err = getBuffer(ctx, pkey)
+		return err
+	}
+
+	_, err = getBuffer(ctx, key)
+	return err
+}
@@ -0,0 +1,106 @@
+package idgen
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ryanreadbooks/folium/internal/segment/dao"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWarmUp(t *testing.T) {
+	defer clean()
+
+	ms := memStore(t)
+	for i := 0; i < 20; i++ {
+		err := testStore.CreateUpdate(ctx, &dao.Alloc{Key: fmt.Sprintf("biz-warm-%d", i), CurId: 1})
+		assert.Nil(t, err)
+	}
+	err := testStore.CreateKey(ctx, &dao.Alloc{Key: periodKey("biz-warm-0", "20261018"), Step: 100})
+	assert.Nil(t, err)
+
+	// record the max number of keys loaded at a time
+	var inflight, peak atomic.Int32
+	ms.SetHook(func(ctx context.Context, op string) error {
+		if op != "TakeIdForKey" {
+			return nil
+		}
+		n := inflight.Add(1)
+		defer inflight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond * 10)
+		return nil
+	})
+
+	ready.Store(false)
+	assert.False(t, Ready())
+	err = WarmUp(ctx, nil, 4)
+	assert.Nil(t, err)
+	assert.True(t, Ready())
+
+	assert.Equal(t, 20, resident)
+	assert.LessOrEqual(t, peak.Load(), int32(4))
+	for i := 0; i < 20; i++ {
+		_, ok := bufs.Load(fmt.Sprintf("biz-warm-%d", i))
+		assert.True(t, ok)
+	}
+}
+
+func TestWarmUp_keys(t *testing.T) {
+	defer clean()
+
+	ms := memStore(t)
+	ms.SetHook(dao.FailOn(fmt.Errorf("db down"), "QueryAllKeys"))
+
+	ready.Store(false)
+	err := WarmUp(ctx, []string{"biz-warm-a", "biz-warm-b"}, 0)
+	assert.Nil(t, err)
+	assert.True(t, Ready())
+	assert.Equal(t, 2, resident)
+
+	// failed keys do not block readiness
+	ready.Store(false)
+	ms.SetHook(dao.FailOn(fmt.Errorf("db down"), "TakeIdForKey"))
+	err = WarmUp(ctx, []string{"biz-warm-c"}, 0)
+	assert.NotNil(t, err)
+	assert.True(t, Ready())
+}
+
+func TestWarmUp_skipKeys(t *testing.T) {
+	defer clean()
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	seqNow = func() time.Time { return now }
+	defer func() { seqNow = time.Now }()
+
+	_, err := CreateKey(ctx, &dao.Alloc{Key: "biz-warm-plain", Step: 10})
+	assert.Nil(t, err)
+	_, err = CreateKey(ctx, &dao.Alloc{Key: "biz-warm-gapless", Step: 10, Gapless: true})
+	assert.Nil(t, err)
+	_, err = CreateKey(ctx, &dao.Alloc{Key: "biz-warm-disabled", Step: 10})
+	assert.Nil(t, err)
+	err = DisableKey(ctx, "biz-warm-disabled")
+	assert.Nil(t, err)
+	_, err = CreateKey(ctx, &dao.Alloc{Key: "biz-warm-seq", Step: 10, Period: dao.ResetDaily})
+	assert.Nil(t, err)
+
+	ready.Store(false)
+	err = WarmUp(ctx, nil, 0)
+	assert.Nil(t, err)
+	assert.True(t, Ready())
+
+	// the current period is loaded instead of the sequence key
+	assert.Equal(t, 2, resident)
+	for _, key := range []string{"biz-warm-plain", "biz-warm-seq#20261018"} {
+		_, ok := bufs.Load(key)
+		assert.True(t, ok, key)
+	}
+}
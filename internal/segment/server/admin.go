@@ -0,0 +1,474 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	apiv1 "github.com/ryanreadbooks/folium/api/v1"
+	"github.com/ryanreadbooks/folium/internal/pkg"
+	"github.com/ryanreadbooks/folium/internal/segment/dao"
+	"github.com/ryanreadbooks/folium/internal/segment/idgen"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+// admin apis of keys, served by both http and grpc. They are only served if an admin token is set,
+// and requests should carry "Authorization: Bearer <token>" in http header or grpc metadata
+
+var (
+	adminToken string
+
+	ErrUnauthenticated = pkg.NewErr(int(codes.Unauthenticated), "admin token is missing or invalid")
+)
+
+func setAdminToken(token string) {
+	adminToken = token
+}
+
+// authorized reports whether the authorization value carries the admin token
+func authorized(auth string) bool {
+	token, ok := strings.CutPrefix(auth, "Bearer ")
+	return ok && adminToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) == 1
+}
+
+func adminAuth(c *gin.Context) {
+	if !authorized(c.GetHeader("Authorization")) {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, &AdminResult{
+			Msg: ErrUnauthenticated.Error(),
+		})
+		return
+	}
+	c.Next()
+}
+
+// adminInterceptor rejects admin service calls without the admin token, calls of other services pass through
+func adminInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if strings.HasPrefix(info.FullMethod, "/"+apiv1.FoliumAdminService_ServiceDesc.ServiceName+"/") {
+		var auth string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if vals := md.Get("authorization"); len(vals) != 0 {
+				auth = vals[0]
+			}
+		}
+		if !authorized(auth) {
+			return nil, grpcErr(ErrUnauthenticated)
+		}
+	}
+	return handler(ctx, req)
+}
+
+func initAdminRoute() {
+	if adminToken == "" {
+		log.Println("admin token is not set, admin http apis are not served")
+		return
+	}
+
+	admin := eng.Group("/api/v1/admin", adminAuth)
+	admin.POST("/keys", createKey)
+	// /api/v1/admin/keys?page_token=xxx&page_size=xxx
+	admin.GET("/keys", listKeys)
+	admin.GET("/keys/:key", getKey)
+	admin.PUT("/keys/:key/step", updateStep)
+	admin.PUT("/keys/:key/format", updateFormat)
+	admin.POST("/keys/:key/disable", setKeyDisabled(true))
+	admin.POST("/keys/:key/enable", setKeyDisabled(false))
+	admin.POST("/keys/:key/jump", jumpAhead)
+	// /api/v1/admin/keys/:key/decode?id=xxx
+	admin.GET("/keys/:key/decode", decodeId)
+}
+
+type AdminResult struct {
+	Msg string `json:"msg,omitempty"`
+
+	Alloc         *apiv1.KeyAlloc    `json:"alloc,omitempty"`
+	Buffer        *apiv1.BufferState `json:"buffer,omitempty"` // buffer state on this node
+	Allocs        []*apiv1.KeyAlloc  `json:"allocs,omitempty"`
+	NextPageToken string             `json:"next_page_token,omitempty"`
+	RawId         uint64             `json:"raw_id,omitempty"` // raw id of obfuscated id
+}
+
+type CreateKeyReq struct {
+	Key           string `json:"key"`
+	CurId         uint64 `json:"cur_id"`
+	Step          uint32 `json:"step"`
+	MinStep       uint32 `json:"min_step"`
+	MaxStep       uint32 `json:"max_step"`
+	MinId         uint64 `json:"min_id"`
+	MaxId         uint64 `json:"max_id"`
+	ExhaustPolicy uint8  `json:"exhaust_policy"` // 0 error, 1 cycle
+	ResetPeriod   uint8  `json:"reset_period"`   // 0 never, 1 daily, 2 monthly
+	TimeZone      string `json:"time_zone"`
+	Format        string `json:"format"`
+	Obfuscate     bool   `json:"obfuscate"`
+	Gapless       bool   `json:"gapless"`
+}
+
+type UpdateStepReq struct {
+	Step    uint32 `json:"step"`
+	MinStep uint32 `json:"min_step"`
+	MaxStep uint32 `json:"max_step"`
+}
+
+type UpdateFormatReq struct {
+	Format string `json:"format"` // empty format removes the format of key
+}
+
+type JumpAheadReq struct {
+	CurId uint64 `json:"cur_id"`
+}
+
+func toKeyAlloc(alloc *dao.Alloc) *apiv1.KeyAlloc {
+	return &apiv1.KeyAlloc{
+		Key:           alloc.Key,
+		CurId:         alloc.CurId,
+		Step:          alloc.Step,
+		MinStep:       alloc.MinStep,
+		MaxStep:       alloc.MaxStep,
+		Disabled:      alloc.Disabled,
+		CreatedAt:     alloc.CreatedAt,
+		UpdatedAt:     alloc.UpdatedAt,
+		MinId:         alloc.MinId,
+		MaxId:         alloc.MaxId,
+		ExhaustPolicy: apiv1.ExhaustPolicy(alloc.Exhaust),
+		ResetPeriod:   apiv1.ResetPeriod(alloc.Period),
+		TimeZone:      alloc.TimeZone,
+		Format:        alloc.Format,
+		Obfuscated:    alloc.Seed != 0,
+		Gapless:       alloc.Gapless,
+	}
+}
+
+func toKeyAllocs(allocs []*dao.Alloc) []*apiv1.KeyAlloc {
+	res := make([]*apiv1.KeyAlloc, 0, len(allocs))
+	for _, alloc := range allocs {
+		res = append(res, toKeyAlloc(alloc))
+	}
+	return res
+}
+
+func toSegmentState(seg *idgen.SegmentState) *apiv1.SegmentState {
+	if seg == nil {
+		return nil
+	}
+	return &apiv1.SegmentState{Begin: seg.Begin, Cur: seg.Cur, Max: seg.Max}
+}
+
+func toBufferState(st *idgen.BufferState) *apiv1.BufferState {
+	if st == nil {
+		return nil
+	}
+	return &apiv1.BufferState{
+		Current:   toSegmentState(&st.Current),
+		Next:      toSegmentState(st.Next),
+		Loading:   st.Loading,
+		Step:      st.Step,
+		MinStep:   st.MinStep,
+		MaxStep:   st.MaxStep,
+		FetchedAt: st.FetchedAt.UnixMilli(),
+		IdleSince: st.IdleSince.UnixMilli(),
+	}
+}
+
+// newSeed returns the obfuscation seed of a new key, zero if the key is not obfuscated
+func newSeed(obfuscate bool) (uint64, error) {
+	if !obfuscate {
+		return 0, nil
+	}
+	return idgen.NewSeed()
+}
+
+func createKey(c *gin.Context) {
+	var req CreateKeyReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, &AdminResult{
+			Msg: pkg.ErrInvalidArgs.Message(err.Error()).Error(),
+		})
+		return
+	}
+
+	seed, err := newSeed(req.Obfuscate)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, &AdminResult{
+			Msg: err.Error(),
+		})
+		return
+	}
+
+	alloc, err := idgen.CreateKey(c, &dao.Alloc{
+		Key:      req.Key,
+		CurId:    req.CurId,
+		Step:     req.Step,
+		MinStep:  req.MinStep,
+		MaxStep:  req.MaxStep,
+		MinId:    req.MinId,
+		MaxId:    req.MaxId,
+		Exhaust:  dao.ExhaustPolicy(req.ExhaustPolicy),
+		Period:   dao.ResetPeriod(req.ResetPeriod),
+		TimeZone: req.TimeZone,
+		Format:   req.Format,
+		Seed:     seed,
+		Gapless:  req.Gapless,
+	})
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, &AdminResult{
+			Msg: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, &AdminResult{
+		Alloc: toKeyAlloc(alloc),
+	})
+}
+
+func listKeys(c *gin.Context) {
+	pageSize, _ := strconv.Atoi(c.Query("page_size"))
+	allocs, next, err := idgen.ListKeys(c, c.Query("page_token"), pageSize)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, &AdminResult{
+			Msg: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, &AdminResult{
+		Allocs:        toKeyAllocs(allocs),
+		NextPageToken: next,
+	})
+}
+
+func getKey(c *gin.Context) {
+	info, err := idgen.GetKey(c, c.Param("key"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, &AdminResult{
+			Msg: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, &AdminResult{
+		Alloc:  toKeyAlloc(info.Alloc),
+		Buffer: toBufferState(info.Buffer),
+	})
+}
+
+func updateStep(c *gin.Context) {
+	var req UpdateStepReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, &AdminResult{
+			Msg: pkg.ErrInvalidArgs.Message(err.Error()).Error(),
+		})
+		return
+	}
+
+	err := idgen.UpdateStep(c, c.Param("key"), req.Step, req.MinStep, req.MaxStep)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, &AdminResult{
+			Msg: err.Error(),
+		})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func updateFormat(c *gin.Context) {
+	var req UpdateFormatReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, &AdminResult{
+			Msg: pkg.ErrInvalidArgs.Message(err.Error()).Error(),
+		})
+		return
+	}
+
+	err := idgen.UpdateFormat(c, c.Param("key"), req.Format)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, &AdminResult{
+			Msg: err.Error(),
+		})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func setKeyDisabled(disabled bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var err error
+		if disabled {
+			err = idgen.DisableKey(c, c.Param("key"))
+		} else {
+			err = idgen.EnableKey(c, c.Param("key"))
+		}
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, &AdminResult{
+				Msg: err.Error(),
+			})
+			return
+		}
+
+		c.Status(http.StatusOK)
+	}
+}
+
+func jumpAhead(c *gin.Context) {
+	var req JumpAheadReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, &AdminResult{
+			Msg: pkg.ErrInvalidArgs.Message(err.Error()).Error(),
+		})
+		return
+	}
+
+	alloc, err := idgen.JumpAhead(c, c.Param("key"), req.CurId)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, &AdminResult{
+			Msg: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, &AdminResult{
+		Alloc: toKeyAlloc(alloc),
+	})
+}
+
+func decodeId(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Query("id"), 10, 64)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, &AdminResult{
+			Msg: pkg.ErrInvalidArgs.Message(err.Error()).Error(),
+		})
+		return
+	}
+
+	raw, err := idgen.DecodeId(c, c.Param("key"), id)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, &AdminResult{
+			Msg: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, &AdminResult{
+		RawId: raw,
+	})
+}
+
+type adminServer struct {
+	apiv1.UnimplementedFoliumAdminServiceServer
+}
+
+func (s *adminServer) CreateKey(ctx context.Context, req *apiv1.CreateKeyRequest) (*apiv1.CreateKeyResponse, error) {
+	seed, err := newSeed(req.Obfuscate)
+	if err != nil {
+		return nil, grpcErr(err)
+	}
+
+	alloc, err := idgen.CreateKey(ctx, &dao.Alloc{
+		Key:      req.Key,
+		CurId:    req.CurId,
+		Step:     req.Step,
+		MinStep:  req.MinStep,
+		MaxStep:  req.MaxStep,
+		MinId:    req.MinId,
+		MaxId:    req.MaxId,
+		Exhaust:  dao.ExhaustPolicy(req.ExhaustPolicy),
+		Period:   dao.ResetPeriod(req.ResetPeriod),
+		TimeZone: req.TimeZone,
+		Format:   req.Format,
+		Seed:     seed,
+		Gapless:  req.Gapless,
+	})
+	if err != nil {
+		return nil, grpcErr(err)
+	}
+
+	return &apiv1.CreateKeyResponse{
+		Alloc: toKeyAlloc(alloc),
+	}, nil
+}
+
+func (s *adminServer) UpdateStep(ctx context.Context, req *apiv1.UpdateStepRequest) (*apiv1.UpdateStepResponse, error) {
+	err := idgen.UpdateStep(ctx, req.Key, req.Step, req.MinStep, req.MaxStep)
+	if err != nil {
+		return nil, grpcErr(err)
+	}
+
+	return &apiv1.UpdateStepResponse{}, nil
+}
+
+func (s *adminServer) UpdateFormat(ctx context.Context, req *apiv1.UpdateFormatRequest) (*apiv1.UpdateFormatResponse, error) {
+	err := idgen.UpdateFormat(ctx, req.Key, req.Format)
+	if err != nil {
+		return nil, grpcErr(err)
+	}
+
+	return &apiv1.UpdateFormatResponse{}, nil
+}
+
+func (s *adminServer) GetKey(ctx context.Context, req *apiv1.GetKeyRequest) (*apiv1.GetKeyResponse, error) {
+	info, err := idgen.GetKey(ctx, req.Key)
+	if err != nil {
+		return nil, grpcErr(err)
+	}
+
+	return &apiv1.GetKeyResponse{
+		Alloc:  toKeyAlloc(info.Alloc),
+		Buffer: toBufferState(info.Buffer),
+	}, nil
+}
+
+func (s *adminServer) ListKeys(ctx context.Context, req *apiv1.ListKeysRequest) (*apiv1.ListKeysResponse, error) {
+	allocs, next, err := idgen.ListKeys(ctx, req.PageToken, int(req.PageSize))
+	if err != nil {
+		return nil, grpcErr(err)
+	}
+
+	return &apiv1.ListKeysResponse{
+		Allocs:        toKeyAllocs(allocs),
+		NextPageToken: next,
+	}, nil
+}
+
+func (s *adminServer) SetKeyDisabled(ctx context.Context, req *apiv1.SetKeyDisabledRequest) (*apiv1.SetKeyDisabledResponse, error) {
+	var err error
+	if req.Disabled {
+		err = idgen.DisableKey(ctx, req.Key)
+	} else {
+		err = idgen.EnableKey(ctx, req.Key)
+	}
+	if err != nil {
+		return nil, grpcErr(err)
+	}
+
+	return &apiv1.SetKeyDisabledResponse{}, nil
+}
+
+func (s *adminServer) JumpAhead(ctx context.Context, req *apiv1.JumpAheadRequest) (*apiv1.JumpAheadResponse, error) {
+	alloc, err := idgen.JumpAhead(ctx, req.Key, req.CurId)
+	if err != nil {
+		return nil, grpcErr(err)
+	}
+
+	return &apiv1.JumpAheadResponse{
+		Alloc: toKeyAlloc(alloc),
+	}, nil
+}
+
+func (s *adminServer) DecodeId(ctx context.Context, req *apiv1.DecodeIdRequest) (*apiv1.DecodeIdResponse, error) {
+	raw, err := idgen.DecodeId(ctx, req.Key, req.Id)
+	if err != nil {
+		return nil, grpcErr(err)
+	}
+
+	return &apiv1.DecodeIdResponse{
+		RawId: raw,
+	}, nil
+}
@@ -5,13 +5,17 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"time"
 
 	apiv1 "github.com/ryanreadbooks/folium/api/v1"
 	"github.com/ryanreadbooks/folium/internal/pkg"
 	"github.com/ryanreadbooks/folium/internal/segment/idgen"
+	"github.com/ryanreadbooks/folium/internal/snowflake"
+	"github.com/ryanreadbooks/folium/internal/uid"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
@@ -19,9 +23,16 @@ var (
 	serverGrpc *grpc.Server
 )
 
-func InitGrpc(port int) {
-	serverGrpc = grpc.NewServer()
+// InitGrpc serves ids on port, the admin service is only served if adminToken is set
+func InitGrpc(port int, adminToken string) {
+	setAdminToken(adminToken)
+	serverGrpc = grpc.NewServer(grpc.UnaryInterceptor(adminInterceptor))
 	apiv1.RegisterFoliumServiceServer(serverGrpc, &grpcServer{})
+	if adminToken != "" {
+		apiv1.RegisterFoliumAdminServiceServer(serverGrpc, &adminServer{})
+	} else {
+		log.Println("admin token is not set, admin grpc service is not served")
+	}
 
 	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
 	if err != nil {
@@ -48,11 +59,7 @@ type grpcServer struct {
 func (s *grpcServer) Next(ctx context.Context, req *apiv1.NextRequest) (*apiv1.NextResponse, error) {
 	id, err := idgen.GetNext(ctx, req.Key, idgen.WithStep(req.Step))
 	if err != nil {
-		pkgerr, ok := err.(*pkg.Err)
-		if ok {
-			return nil, status.Error(codes.Code(pkgerr.Code), pkgerr.Msg)
-		}
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, grpcErr(err)
 	}
 
 	return &apiv1.NextResponse{
@@ -60,6 +67,155 @@ func (s *grpcServer) Next(ctx context.Context, req *apiv1.NextRequest) (*apiv1.N
 	}, nil
 }
 
+func (s *grpcServer) NextBatch(ctx context.Context, req *apiv1.NextBatchRequest) (*apiv1.NextBatchResponse, error) {
+	ids, err := idgen.GetNextBatch(ctx, req.Key, req.Count, idgen.WithStep(req.Step))
+	if err != nil {
+		return nil, grpcErr(err)
+	}
+
+	return &apiv1.NextBatchResponse{
+		Ids: ids,
+	}, nil
+}
+
+func (s *grpcServer) LeaseRange(ctx context.Context, req *apiv1.LeaseRangeRequest) (*apiv1.LeaseRangeResponse, error) {
+	who := "unknown"
+	if p, ok := peer.FromContext(ctx); ok {
+		who = p.Addr.String()
+	}
+
+	res, err := idgen.LeaseRange(ctx, req.Key, req.Size, who)
+	if err != nil {
+		return nil, grpcErr(err)
+	}
+
+	return &apiv1.LeaseRangeResponse{
+		Begin: res.Begin,
+		End:   res.End,
+	}, nil
+}
+
+func (s *grpcServer) NextSnowflake(ctx context.Context, req *apiv1.NextSnowflakeRequest) (*apiv1.NextSnowflakeResponse, error) {
+	id, err := snowflake.GetNext(ctx)
+	if err != nil {
+		return nil, grpcErr(err)
+	}
+
+	return &apiv1.NextSnowflakeResponse{
+		Id: id,
+	}, nil
+}
+
+func (s *grpcServer) NextSeq(ctx context.Context, req *apiv1.NextSeqRequest) (*apiv1.NextSeqResponse, error) {
+	period, seq, err := idgen.GetNextSeq(ctx, req.Key)
+	if err != nil {
+		return nil, grpcErr(err)
+	}
+
+	return &apiv1.NextSeqResponse{
+		Period: period,
+		Seq:    seq,
+	}, nil
+}
+
+func (s *grpcServer) NextFormatted(ctx context.Context, req *apiv1.NextFormattedRequest) (*apiv1.NextFormattedResponse, error) {
+	formatted, id, err := idgen.GetNextFormatted(ctx, req.Key)
+	if err != nil {
+		return nil, grpcErr(err)
+	}
+
+	return &apiv1.NextFormattedResponse{
+		Id:        id,
+		Formatted: formatted,
+	}, nil
+}
+
+func (s *grpcServer) NextUUIDv7(ctx context.Context, req *apiv1.NextUUIDv7Request) (*apiv1.NextUUIDv7Response, error) {
+	id, err := uid.GetUUIDv7(ctx)
+	if err != nil {
+		return nil, grpcErr(err)
+	}
+
+	return &apiv1.NextUUIDv7Response{
+		Uuid: id,
+	}, nil
+}
+
+func (s *grpcServer) NextUUIDv7Batch(ctx context.Context, req *apiv1.NextUUIDv7BatchRequest) (*apiv1.NextUUIDv7BatchResponse, error) {
+	ids, err := uid.GetUUIDv7Batch(ctx, req.Count)
+	if err != nil {
+		return nil, grpcErr(err)
+	}
+
+	return &apiv1.NextUUIDv7BatchResponse{
+		Uuids: ids,
+	}, nil
+}
+
+func (s *grpcServer) NextULID(ctx context.Context, req *apiv1.NextULIDRequest) (*apiv1.NextULIDResponse, error) {
+	id, err := uid.GetULID(ctx)
+	if err != nil {
+		return nil, grpcErr(err)
+	}
+
+	return &apiv1.NextULIDResponse{
+		Ulid: id,
+	}, nil
+}
+
+func (s *grpcServer) NextULIDBatch(ctx context.Context, req *apiv1.NextULIDBatchRequest) (*apiv1.NextULIDBatchResponse, error) {
+	ids, err := uid.GetULIDBatch(ctx, req.Count)
+	if err != nil {
+		return nil, grpcErr(err)
+	}
+
+	return &apiv1.NextULIDBatchResponse{
+		Ulids: ids,
+	}, nil
+}
+
+func (s *grpcServer) ReserveNum(ctx context.Context, req *apiv1.ReserveNumRequest) (*apiv1.ReserveNumResponse, error) {
+	r, err := idgen.ReserveNum(ctx, req.Key, time.Duration(req.TtlMs)*time.Millisecond)
+	if err != nil {
+		return nil, grpcErr(err)
+	}
+
+	return &apiv1.ReserveNumResponse{
+		Num:      r.Num,
+		Token:    r.Token,
+		ExpireAt: r.ExpireAt,
+	}, nil
+}
+
+func (s *grpcServer) ConfirmNum(ctx context.Context, req *apiv1.ConfirmNumRequest) (*apiv1.ConfirmNumResponse, error) {
+	if err := idgen.ConfirmNum(ctx, req.Key, req.Num, req.Token); err != nil {
+		return nil, grpcErr(err)
+	}
+
+	return &apiv1.ConfirmNumResponse{}, nil
+}
+
+func (s *grpcServer) ReleaseNum(ctx context.Context, req *apiv1.ReleaseNumRequest) (*apiv1.ReleaseNumResponse, error) {
+	if err := idgen.ReleaseNum(ctx, req.Key, req.Num, req.Token); err != nil {
+		return nil, grpcErr(err)
+	}
+
+	return &apiv1.ReleaseNumResponse{}, nil
+}
+
 func (s *grpcServer) Ping(ctx context.Context, in *apiv1.PingRequest) (*apiv1.PingResponse, error) {
+	if !idgen.Ready() {
+		return nil, grpcErr(idgen.ErrNotReady)
+	}
+
 	return &apiv1.PingResponse{}, nil
 }
+
+// convert err into grpc status error
+func grpcErr(err error) error {
+	pkgerr, ok := err.(*pkg.Err)
+	if ok {
+		return status.Error(codes.Code(pkgerr.Code), pkgerr.Msg)
+	}
+	return status.Error(codes.Internal, err.Error())
+}
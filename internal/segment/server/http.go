@@ -1,14 +1,20 @@
 package server
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/ryanreadbooks/folium/internal/pkg"
 	"github.com/ryanreadbooks/folium/internal/segment/idgen"
+	"github.com/ryanreadbooks/folium/internal/snowflake"
+	"github.com/ryanreadbooks/folium/internal/uid"
 )
 
 var (
@@ -16,17 +22,16 @@ var (
 	eng        *gin.Engine
 )
 
-func init() {
-	idgen.Init()
-}
-
 func CloseServer() {
 	CloseHttp()
 	CloseGrpc()
+	snowflake.Close() // worker lease should be released before db is closed
 	idgen.Close()
 }
 
-func InitHttp(port int) {
+// InitHttp serves ids on port, admin apis are only served if adminToken is set
+func InitHttp(port int, adminToken string) {
+	setAdminToken(adminToken)
 	initRoute()
 
 	go func() {
@@ -48,12 +53,59 @@ func initRoute() {
 
 	// /api/v1/next/:key?step=xxx
 	eng.GET("/api/v1/next/:key", nextForKey)
+	eng.POST("/api/v1/next/:key/batch", nextBatchForKey)
+	eng.POST("/api/v1/lease/:key", leaseRangeForKey)
+	eng.GET("/api/v1/snowflake", nextSnowflake)
+	eng.GET("/api/v1/seq/:key", nextSeqForKey)
+	eng.GET("/api/v1/formatted/:key", nextFormattedForKey)
+	eng.GET("/api/v1/uuidv7", nextUid(uid.GetUUIDv7))
+	eng.POST("/api/v1/uuidv7/batch", nextUidBatch(uid.GetUUIDv7Batch))
+	eng.GET("/api/v1/ulid", nextUid(uid.GetULID))
+	eng.POST("/api/v1/ulid/batch", nextUidBatch(uid.GetULIDBatch))
+	eng.POST("/api/v1/gapless/:key/reserve", reserveNumForKey)
+	eng.POST("/api/v1/gapless/:key/confirm", settleNumForKey(idgen.ConfirmNum))
+	eng.POST("/api/v1/gapless/:key/release", settleNumForKey(idgen.ReleaseNum))
 	eng.GET("/api/v1/health", health)
+
+	initAdminRoute()
 }
 
 type Result struct {
-	Id  uint64 `json:"id,omitempty"`
-	Msg string `json:"msg,omitempty"`
+	Id  uint64   `json:"id,omitempty"`
+	Ids []uint64 `json:"ids,omitempty"`
+	Msg string   `json:"msg,omitempty"`
+
+	Begin uint64 `json:"begin,omitempty"` // begin of leased range, inclusive
+	End   uint64 `json:"end,omitempty"`   // end of leased range, exclusive
+
+	Period string `json:"period,omitempty"` // period of date scoped sequence
+	Seq    uint64 `json:"seq,omitempty"`    // sequence number in period
+
+	Formatted string `json:"formatted,omitempty"` // id rendered with the format of key
+
+	Uid  string   `json:"uid,omitempty"`  // uuidv7 or ulid
+	Uids []string `json:"uids,omitempty"` // uuidv7s or ulids
+
+	Token    string `json:"token,omitempty"`     // token of the number reserved in id
+	ExpireAt int64  `json:"expire_at,omitempty"` // unix ms when the reservation expires
+}
+
+type BatchReq struct {
+	Count uint32 `json:"count"`
+	Step  uint32 `json:"step"`
+}
+
+type LeaseReq struct {
+	Size uint32 `json:"size"`
+}
+
+type ReserveReq struct {
+	TtlMs uint32 `json:"ttl_ms"` // 0 means 1 minute
+}
+
+type SettleReq struct {
+	Num   uint64 `json:"num"`
+	Token string `json:"token"`
 }
 
 func nextForKey(c *gin.Context) {
@@ -73,6 +125,191 @@ func nextForKey(c *gin.Context) {
 	})
 }
 
+func nextBatchForKey(c *gin.Context) {
+	key := c.Param("key")
+	var req BatchReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, &Result{
+			Msg: pkg.ErrInvalidArgs.Message(err.Error()).Error(),
+		})
+		return
+	}
+
+	ids, err := idgen.GetNextBatch(c, key, req.Count, idgen.WithStep(req.Step))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, &Result{
+			Msg: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, &Result{
+		Ids: ids,
+	})
+}
+
+func leaseRangeForKey(c *gin.Context) {
+	key := c.Param("key")
+	var req LeaseReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, &Result{
+			Msg: pkg.ErrInvalidArgs.Message(err.Error()).Error(),
+		})
+		return
+	}
+
+	res, err := idgen.LeaseRange(c, key, req.Size, c.ClientIP())
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, &Result{
+			Msg: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, &Result{
+		Begin: res.Begin,
+		End:   res.End,
+	})
+}
+
+func nextSnowflake(c *gin.Context) {
+	id, err := snowflake.GetNext(c)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, &Result{
+			Msg: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, &Result{
+		Id: id,
+	})
+}
+
+func nextSeqForKey(c *gin.Context) {
+	period, seq, err := idgen.GetNextSeq(c, c.Param("key"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, &Result{
+			Msg: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, &Result{
+		Period: period,
+		Seq:    seq,
+	})
+}
+
+func nextFormattedForKey(c *gin.Context) {
+	formatted, id, err := idgen.GetNextFormatted(c, c.Param("key"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, &Result{
+			Msg: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, &Result{
+		Id:        id,
+		Formatted: formatted,
+	})
+}
+
+func nextUid(get func(context.Context) (string, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := get(c)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, &Result{
+				Msg: err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, &Result{
+			Uid: id,
+		})
+	}
+}
+
+func nextUidBatch(get func(context.Context, uint32) ([]string, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req BatchReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, &Result{
+				Msg: pkg.ErrInvalidArgs.Message(err.Error()).Error(),
+			})
+			return
+		}
+
+		ids, err := get(c, req.Count)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, &Result{
+				Msg: err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, &Result{
+			Uids: ids,
+		})
+	}
+}
+
+func reserveNumForKey(c *gin.Context) {
+	var req ReserveReq
+	// empty body takes the default ttl
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.AbortWithStatusJSON(http.StatusBadRequest, &Result{
+			Msg: pkg.ErrInvalidArgs.Message(err.Error()).Error(),
+		})
+		return
+	}
+
+	r, err := idgen.ReserveNum(c, c.Param("key"), time.Duration(req.TtlMs)*time.Millisecond)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, &Result{
+			Msg: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, &Result{
+		Id:       r.Num,
+		Token:    r.Token,
+		ExpireAt: r.ExpireAt,
+	})
+}
+
+// settleNumForKey confirms or releases the reserved number of key
+func settleNumForKey(settle func(context.Context, string, uint64, string) error) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req SettleReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, &Result{
+				Msg: pkg.ErrInvalidArgs.Message(err.Error()).Error(),
+			})
+			return
+		}
+
+		if err := settle(c, c.Param("key"), req.Num, req.Token); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, &Result{
+				Msg: err.Error(),
+			})
+			return
+		}
+
+		c.Status(http.StatusOK)
+	}
+}
+
 func health(c *gin.Context) {
+	if !idgen.Ready() {
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, &Result{
+			Msg: idgen.ErrNotReady.Error(),
+		})
+		return
+	}
+
 	c.Status(http.StatusOK)
 }
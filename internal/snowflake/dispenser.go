@@ -0,0 +1,227 @@
+package snowflake
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/ryanreadbooks/folium/internal/pkg"
+	"github.com/ryanreadbooks/folium/internal/segment/dao"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	defaultMaxClockSkew = time.Second
+)
+
+var (
+	closed atomic.Bool
+
+	gen     *generator
+	closeCh chan struct{}
+	doneCh  chan struct{}
+)
+
+var (
+	ErrClosed    = pkg.NewErr(int(codes.Unavailable), "snowflake dispenser is closed")
+	ErrNotInited = pkg.NewErr(int(codes.Unavailable), "snowflake dispenser is not inited")
+)
+
+type Config struct {
+	Store           dao.LeaseStore // store where worker ids are leased from, it is required if WorkerId is negative
+	Layout          Layout
+	WorkerId        int64         // fixed worker id of this node, negative means leasing one from db
+	LeaseTTL        time.Duration // ttl of the leased worker id
+	SpareWorker     bool          // lease a spare worker id to switch to when clock moves backwards
+	MaxBackwardWait time.Duration // clock rollback within this duration is waited out
+	MaxClockSkew    time.Duration // max allowed clock skew from db at startup
+}
+
+// init snowflake with bit layout and worker id of this node
+func Init(c Config) error {
+	var workerId uint64
+	if c.WorkerId >= 0 {
+		workerId = uint64(c.WorkerId)
+	}
+
+	g, err := newGenerator(c.Layout, workerId)
+	if err != nil {
+		return err
+	}
+
+	if c.MaxBackwardWait > 0 {
+		g.maxWait = c.MaxBackwardWait.Milliseconds()
+	}
+
+	if c.WorkerId < 0 {
+		if c.Store == nil {
+			return fmt.Errorf("snowflake worker id is not given and there is no store to lease one from")
+		}
+		if c.LeaseTTL <= 0 {
+			c.LeaseTTL = defaultLeaseTTL
+		}
+		if c.MaxClockSkew <= 0 {
+			c.MaxClockSkew = defaultMaxClockSkew
+		}
+
+		ctx := context.Background()
+		if err = checkClockSkew(ctx, c.Store, c.MaxClockSkew); err != nil {
+			return err
+		}
+
+		owner := leaseOwner()
+		l, err := claimLease(ctx, c.Store, owner, c.Layout.MaxWorkerId(), c.LeaseTTL)
+		if err != nil {
+			return err
+		}
+		if err = g.setLease(l); err != nil {
+			l.release(ctx, l.lastTs)
+			return err
+		}
+
+		if c.SpareWorker {
+			spare, err := claimLease(ctx, c.Store, owner, c.Layout.MaxWorkerId(), c.LeaseTTL)
+			if err != nil {
+				l.release(ctx, l.lastTs)
+				return err
+			}
+			if err = g.setSpare(spare); err != nil {
+				l.release(ctx, l.lastTs)
+				spare.release(ctx, spare.lastTs)
+				return err
+			}
+		}
+
+		closeCh = make(chan struct{})
+		doneCh = make(chan struct{})
+		go heartbeat(g, c.SpareWorker, closeCh, doneCh)
+	}
+
+	gen = g
+	closed.Store(false)
+	log.Printf("snowflake inited with layout %+v, worker id %d\n", c.Layout, g.workerId)
+
+	return nil
+}
+
+// make sure local clock is close to db clock
+func checkClockSkew(ctx context.Context, store dao.LeaseStore, maxSkew time.Duration) error {
+	before := time.Now().UnixMilli()
+	dbNow, err := store.QueryNowMs(ctx)
+	if err != nil {
+		return err
+	}
+	after := time.Now().UnixMilli()
+
+	// db time is compared with the middle of the round trip
+	skew := (before+after)/2 - dbNow
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew > maxSkew.Milliseconds() {
+		log.Printf("snowflake local clock skews %dms from db\n", skew)
+		return ErrClockSkew.Message(fmt.Sprintf("%s: %dms", ErrClockSkew.Msg, skew))
+	}
+
+	return nil
+}
+
+// heartbeat keeps the worker leases alive, a new worker id will be claimed if the lease is lost
+func heartbeat(g *generator, useSpare bool, closeCh, doneCh chan struct{}) {
+	defer close(doneCh)
+
+	l, _, _ := g.leases()
+	ticker := time.NewTicker(l.ttl / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), l.ttl/3)
+			keepLeases(ctx, g, useSpare)
+			cancel()
+		case old := <-g.retired:
+			// worker id switched out because of clock rollback
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+			old.release(ctx, old.lastTs)
+			cancel()
+		case <-closeCh:
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+			cur, spare, lastTs := g.leases()
+			cur.release(ctx, lastTs)
+			if spare != nil {
+				spare.release(ctx, spare.lastTs)
+			}
+			cancel()
+			return
+		}
+	}
+}
+
+func keepLeases(ctx context.Context, g *generator, useSpare bool) {
+	cur, spare, lastTs := g.leases()
+	err := cur.renew(ctx, lastTs)
+	if err != nil {
+		log.Printf("snowflake renew worker id %d err: %v\n", cur.workerId, err)
+	}
+	if cur.expireAt.Load() == 0 {
+		// lease is lost, we have to claim another worker id
+		nl, err := claimLease(ctx, cur.store, cur.owner, g.layout.MaxWorkerId(), cur.ttl)
+		if err != nil {
+			log.Printf("snowflake claim worker id err: %v\n", err)
+		} else if err = g.setLease(nl); err != nil {
+			log.Printf("snowflake set lease err: %v\n", err)
+			nl.release(ctx, nl.lastTs)
+		}
+	}
+
+	if !useSpare {
+		return
+	}
+
+	if spare != nil {
+		err = spare.renew(ctx, spare.lastTs)
+		if err == nil {
+			return
+		}
+		log.Printf("snowflake renew spare worker id %d err: %v\n", spare.workerId, err)
+		if spare.expireAt.Load() != 0 {
+			return
+		}
+	}
+
+	// spare is used or lost, claim a new one
+	ns, err := claimLease(ctx, cur.store, cur.owner, g.layout.MaxWorkerId(), cur.ttl)
+	if err != nil {
+		log.Printf("snowflake claim spare worker id err: %v\n", err)
+		return
+	}
+	if err = g.setSpare(ns); err != nil {
+		log.Printf("snowflake set spare lease err: %v\n", err)
+		ns.release(ctx, ns.lastTs)
+	}
+}
+
+// GetNext returns the next snowflake id
+func GetNext(ctx context.Context) (uint64, error) {
+	if closed.Load() {
+		return 0, ErrClosed
+	}
+
+	if gen == nil {
+		return 0, ErrNotInited
+	}
+
+	return gen.next()
+}
+
+// Close stops dispensing ids and releases the leased worker id
+func Close() {
+	closed.Store(true)
+	if closeCh != nil {
+		close(closeCh)
+		<-doneCh
+		closeCh = nil
+	}
+}
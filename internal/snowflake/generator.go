@@ -0,0 +1,178 @@
+package snowflake
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/ryanreadbooks/folium/internal/pkg"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	defaultMaxBackwardWait = time.Millisecond * 5
+)
+
+var (
+	ErrTimeOverflow   = pkg.ErrInternal.Message("snowflake timestamp overflows layout")
+	ErrClockBackwards = pkg.NewErr(int(codes.Aborted), "snowflake clock moved backwards")
+	ErrClockSkew      = pkg.NewErr(int(codes.FailedPrecondition), "snowflake clock skews too much from db")
+	ErrLeaseExpired   = pkg.NewErr(int(codes.Unavailable), "snowflake worker lease is expired")
+)
+
+// generator dispenses time-ordered ids for one worker id
+type generator struct {
+	sync.Mutex
+
+	layout   Layout
+	workerId uint64
+	lastTs   int64 // last used unix ms
+	seq      uint64
+	lease    *lease // nil if worker id is fixed
+	spare    *lease // spare worker id to switch to when clock moves backwards, can be nil
+
+	maxWait int64       // max ms to wait for when clock moves backwards
+	retired chan *lease // leases which are switched out and should be released
+
+	now func() int64 // returns unix ms
+}
+
+func newGenerator(layout Layout, workerId uint64) (*generator, error) {
+	if err := layout.Validate(); err != nil {
+		return nil, err
+	}
+
+	if workerId > layout.MaxWorkerId() {
+		return nil, fmt.Errorf("snowflake worker id %d exceeds max worker id %d", workerId, layout.MaxWorkerId())
+	}
+
+	return &generator{
+		layout:   layout,
+		workerId: workerId,
+		maxWait:  defaultMaxBackwardWait.Milliseconds(),
+		retired:  make(chan *lease, 1),
+		now:      func() int64 { return time.Now().UnixMilli() },
+	}, nil
+}
+
+func (g *generator) next() (uint64, error) {
+	g.Lock()
+	defer g.Unlock()
+
+	ts := g.now()
+	if ts < g.lastTs {
+		var err error
+		ts, err = g.handleBackwards(ts)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if g.lease != nil && !g.lease.valid(ts) {
+		// we may not own the worker id anymore
+		return 0, ErrLeaseExpired
+	}
+
+	if ts == g.lastTs {
+		g.seq = (g.seq + 1) & g.layout.maxSeq()
+		if g.seq == 0 {
+			// sequence is used up in this millisecond, wait for the next one
+			ts = g.tilNextMs(g.lastTs)
+		}
+	} else {
+		g.seq = 0
+	}
+
+	elapsed := ts - g.layout.Epoch
+	if elapsed > g.layout.maxElapsed() {
+		return 0, ErrTimeOverflow
+	}
+
+	g.lastTs = ts
+	return g.layout.compose(elapsed, g.workerId, g.seq), nil
+}
+
+// handleBackwards is called when clock ts is behind last used timestamp.
+//
+// A small rollback is waited out, otherwise we switch to the spare worker id if there is one
+// which has never been used at ts, or we refuse to dispense ids.
+func (g *generator) handleBackwards(ts int64) (int64, error) {
+	delta := g.lastTs - ts
+	if delta <= g.maxWait {
+		for ts < g.lastTs {
+			time.Sleep(time.Microsecond * 100)
+			ts = g.now()
+		}
+		return ts, nil
+	}
+
+	spare := g.spare
+	if spare != nil && g.lease != nil && spare.valid(ts) && spare.lastTs < ts {
+		old := g.lease
+		old.lastTs = g.lastTs
+		select {
+		case g.retired <- old:
+		default:
+			// the previous retired lease is not released yet, we can not switch
+			log.Printf("snowflake clock moved backwards %dms, no spare worker id is ready\n", delta)
+			return 0, ErrClockBackwards
+		}
+
+		g.workerId = spare.workerId
+		g.lease = spare
+		g.spare = nil
+		g.lastTs = spare.lastTs
+		g.seq = 0
+		log.Printf("snowflake clock moved backwards %dms, switch worker id from %d to %d\n",
+			delta, old.workerId, spare.workerId)
+		return ts, nil
+	}
+
+	log.Printf("snowflake clock moved backwards %dms, refuse to dispense ids\n", delta)
+	return 0, ErrClockBackwards
+}
+
+// switch to a newly leased worker id
+func (g *generator) setLease(l *lease) error {
+	if l.workerId > g.layout.MaxWorkerId() {
+		return fmt.Errorf("snowflake worker id %d exceeds max worker id %d", l.workerId, g.layout.MaxWorkerId())
+	}
+
+	g.Lock()
+	defer g.Unlock()
+	g.workerId = l.workerId
+	g.lease = l
+	// never dispense ids at timestamps which may have been used by the previous lease holder
+	if l.lastTs > g.lastTs {
+		g.lastTs = l.lastTs
+	}
+	return nil
+}
+
+func (g *generator) setSpare(l *lease) error {
+	if l.workerId > g.layout.MaxWorkerId() {
+		return fmt.Errorf("snowflake worker id %d exceeds max worker id %d", l.workerId, g.layout.MaxWorkerId())
+	}
+
+	g.Lock()
+	defer g.Unlock()
+	g.spare = l
+	return nil
+}
+
+// returns current lease, spare lease and last used unix ms
+func (g *generator) leases() (*lease, *lease, int64) {
+	g.Lock()
+	defer g.Unlock()
+	return g.lease, g.spare, g.lastTs
+}
+
+func (g *generator) tilNextMs(last int64) int64 {
+	ts := g.now()
+	for ts <= last {
+		time.Sleep(time.Microsecond * 100)
+		ts = g.now()
+	}
+	return ts
+}
@@ -0,0 +1,224 @@
+package snowflake
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ryanreadbooks/folium/internal/pkg/misc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLayout_Validate(t *testing.T) {
+	assert.Nil(t, DefaultLayout().Validate())
+
+	l := DefaultLayout()
+	l.SeqBits = 0
+	assert.NotNil(t, l.Validate())
+
+	l = DefaultLayout()
+	l.TimeBits = 42
+	assert.NotNil(t, l.Validate())
+}
+
+func TestLayout_Decompose(t *testing.T) {
+	l := DefaultLayout()
+	id := l.compose(12345, 7, 89)
+	ts, workerId, seq := l.Decompose(id)
+	assert.EqualValues(t, 12345+l.Epoch, ts)
+	assert.EqualValues(t, 7, workerId)
+	assert.EqualValues(t, 89, seq)
+}
+
+func TestGenerator_next(t *testing.T) {
+	_, err := newGenerator(DefaultLayout(), 1024)
+	assert.NotNil(t, err)
+
+	g, err := newGenerator(DefaultLayout(), 3)
+	assert.Nil(t, err)
+
+	prev, err := g.next()
+	assert.Nil(t, err)
+	for i := 0; i < 10000; i++ {
+		id, err := g.next()
+		assert.Nil(t, err)
+		assert.Greater(t, id, prev)
+		prev = id
+	}
+
+	_, workerId, _ := g.layout.Decompose(prev)
+	assert.EqualValues(t, 3, workerId)
+}
+
+func TestGenerator_seqExhausted(t *testing.T) {
+	l := DefaultLayout()
+	l.SeqBits = 2
+	g, err := newGenerator(l, 0)
+	assert.Nil(t, err)
+
+	var ms int64 = l.Epoch + 100
+	calls := 0
+	g.now = func() int64 {
+		calls++
+		// move forward one millisecond after sequence is exhausted
+		if calls > 5 {
+			return ms + 1
+		}
+		return ms
+	}
+
+	ids := make([]uint64, 0, 5)
+	for i := 0; i < 5; i++ {
+		id, err := g.next()
+		assert.Nil(t, err)
+		ids = append(ids, id)
+	}
+
+	ts, _, seq := l.Decompose(ids[4])
+	assert.EqualValues(t, ms+1, ts)
+	assert.EqualValues(t, 0, seq)
+	assert.False(t, misc.HasDupElems(ids))
+}
+
+func TestGenerator_clockBackwards(t *testing.T) {
+	g, err := newGenerator(DefaultLayout(), 0)
+	assert.Nil(t, err)
+
+	var ms int64 = g.layout.Epoch + 100
+	g.now = func() int64 { return ms }
+	_, err = g.next()
+	assert.Nil(t, err)
+
+	ms -= 10
+	_, err = g.next()
+	assert.Equal(t, ErrClockBackwards, err)
+}
+
+func TestGenerator_concurrentNext(t *testing.T) {
+	g, err := newGenerator(DefaultLayout(), 1)
+	assert.Nil(t, err)
+
+	var (
+		wg   sync.WaitGroup
+		lock sync.Mutex
+		num  = 100
+		per  = 1000
+	)
+
+	ids := make([]uint64, 0, num*per)
+	for i := 0; i < num; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < per; j++ {
+				id, err := g.next()
+				assert.Nil(t, err)
+				lock.Lock()
+				ids = append(ids, id)
+				lock.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+	assert.EqualValues(t, false, misc.HasDupElems(ids))
+}
+
+func TestGenerator_leaseExpired(t *testing.T) {
+	g, err := newGenerator(DefaultLayout(), 0)
+	assert.Nil(t, err)
+
+	var ms int64 = g.layout.Epoch + 100
+	g.now = func() int64 { return ms }
+
+	l := &lease{workerId: 5, ttl: time.Second * 30}
+	l.expireAt.Store(ms + l.ttl.Milliseconds())
+	assert.Nil(t, g.setLease(l))
+
+	id, err := g.next()
+	assert.Nil(t, err)
+	_, workerId, _ := g.layout.Decompose(id)
+	assert.EqualValues(t, 5, workerId)
+
+	// lease is about to expire
+	ms += l.ttl.Milliseconds() - leaseGuard.Milliseconds()
+	_, err = g.next()
+	assert.Equal(t, ErrLeaseExpired, err)
+
+	assert.NotNil(t, g.setLease(&lease{workerId: 1024}))
+}
+
+func TestGenerator_waitBackwards(t *testing.T) {
+	g, err := newGenerator(DefaultLayout(), 0)
+	assert.Nil(t, err)
+
+	var ms int64 = g.layout.Epoch + 100
+	calls := 0
+	g.now = func() int64 {
+		calls++
+		// clock moves back 3ms and catches up later
+		if calls == 2 {
+			return ms - 3
+		}
+		return ms
+	}
+
+	id1, err := g.next()
+	assert.Nil(t, err)
+	id2, err := g.next()
+	assert.Nil(t, err)
+	assert.Greater(t, id2, id1)
+}
+
+func TestGenerator_switchSpare(t *testing.T) {
+	g, err := newGenerator(DefaultLayout(), 0)
+	assert.Nil(t, err)
+
+	var ms int64 = g.layout.Epoch + 100000
+	g.now = func() int64 { return ms }
+
+	cur := &lease{workerId: 1, ttl: time.Minute}
+	cur.expireAt.Store(ms + time.Hour.Milliseconds())
+	assert.Nil(t, g.setLease(cur))
+
+	_, err = g.next()
+	assert.Nil(t, err)
+
+	// no spare worker id
+	ms -= 1000
+	_, err = g.next()
+	assert.Equal(t, ErrClockBackwards, err)
+
+	spare := &lease{workerId: 2, ttl: time.Minute}
+	spare.expireAt.Store(ms + time.Hour.Milliseconds())
+	assert.Nil(t, g.setSpare(spare))
+
+	id, err := g.next()
+	assert.Nil(t, err)
+	_, workerId, _ := g.layout.Decompose(id)
+	assert.EqualValues(t, 2, workerId)
+
+	retired := <-g.retired
+	assert.EqualValues(t, 1, retired.workerId)
+	assert.EqualValues(t, ms+1000, retired.lastTs)
+}
+
+func TestGenerator_leaseLastTs(t *testing.T) {
+	g, err := newGenerator(DefaultLayout(), 0)
+	assert.Nil(t, err)
+
+	var ms int64 = g.layout.Epoch + 100000
+	g.now = func() int64 { return ms }
+
+	// previous holder of the worker id used a later timestamp
+	l := &lease{workerId: 1, ttl: time.Minute, lastTs: ms + 1000}
+	l.expireAt.Store(ms + time.Hour.Milliseconds())
+	assert.Nil(t, g.setLease(l))
+
+	_, err = g.next()
+	assert.Equal(t, ErrClockBackwards, err)
+
+	ms += 1001
+	_, err = g.next()
+	assert.Nil(t, err)
+}
@@ -0,0 +1,77 @@
+package snowflake
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// the highest bit is always 0 so that ids stay positive as int64
+	maxTotalBits = 63
+)
+
+var (
+	// 2024-01-01 00:00:00 UTC
+	defaultEpoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).UnixMilli()
+)
+
+// Layout describes how the bits of a snowflake id are split
+//
+//	| 0 | timestamp (TimeBits) | worker id (WorkerBits) | sequence (SeqBits) |
+type Layout struct {
+	TimeBits   uint8 // bits for milliseconds elapsed since Epoch
+	WorkerBits uint8 // bits for worker id
+	SeqBits    uint8 // bits for sequence within the same millisecond
+	Epoch      int64 // custom epoch in unix ms
+}
+
+func DefaultLayout() Layout {
+	return Layout{
+		TimeBits:   41,
+		WorkerBits: 10,
+		SeqBits:    12,
+		Epoch:      defaultEpoch,
+	}
+}
+
+func (l Layout) Validate() error {
+	if l.TimeBits == 0 || l.WorkerBits == 0 || l.SeqBits == 0 {
+		return fmt.Errorf("snowflake layout bits can not be zero: %+v", l)
+	}
+
+	total := int(l.TimeBits) + int(l.WorkerBits) + int(l.SeqBits)
+	if total > maxTotalBits {
+		return fmt.Errorf("snowflake layout takes %d bits, at most %d bits are allowed", total, maxTotalBits)
+	}
+
+	if l.Epoch < 0 || l.Epoch > time.Now().UnixMilli() {
+		return fmt.Errorf("snowflake epoch %d is invalid", l.Epoch)
+	}
+
+	return nil
+}
+
+func (l Layout) MaxWorkerId() uint64 {
+	return 1<<l.WorkerBits - 1
+}
+
+func (l Layout) maxSeq() uint64 {
+	return 1<<l.SeqBits - 1
+}
+
+func (l Layout) maxElapsed() int64 {
+	return 1<<l.TimeBits - 1
+}
+
+// compose id from its parts, parts are supposed to be in range
+func (l Layout) compose(elapsed int64, workerId, seq uint64) uint64 {
+	return uint64(elapsed)<<(l.WorkerBits+l.SeqBits) | workerId<<l.SeqBits | seq
+}
+
+// Decompose splits id into unix ms timestamp, worker id and sequence
+func (l Layout) Decompose(id uint64) (ts int64, workerId uint64, seq uint64) {
+	seq = id & l.maxSeq()
+	workerId = (id >> l.SeqBits) & l.MaxWorkerId()
+	ts = int64(id>>(l.WorkerBits+l.SeqBits)) + l.Epoch
+	return
+}
@@ -0,0 +1,86 @@
+package snowflake
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/ryanreadbooks/folium/internal/segment/dao"
+)
+
+const (
+	defaultLeaseTTL = time.Second * 30
+
+	// stop issuing ids a little earlier than the lease really expires
+	leaseGuard = time.Second
+)
+
+// lease represents a worker id leased from db
+type lease struct {
+	store    dao.LeaseStore
+	workerId uint64
+	owner    string
+	ttl      time.Duration
+	expireAt atomic.Int64 // unix ms
+	lastTs   int64        // last used unix ms persisted in db when the lease is claimed
+}
+
+func claimLease(ctx context.Context, store dao.LeaseStore, owner string, maxWorkerId uint64, ttl time.Duration) (*lease, error) {
+	wl, err := store.ClaimWorker(ctx, owner, maxWorkerId, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &lease{
+		store:    store,
+		workerId: wl.WorkerId,
+		owner:    owner,
+		ttl:      ttl,
+		lastTs:   wl.LastTs,
+	}
+	l.expireAt.Store(wl.ExpireAt)
+	log.Printf("snowflake worker id %d leased by %s until %d\n", l.workerId, owner, wl.ExpireAt)
+
+	return l, nil
+}
+
+// check if lease is still valid at unix ms now
+func (l *lease) valid(now int64) bool {
+	return now < l.expireAt.Load()-leaseGuard.Milliseconds()
+}
+
+// renew lease and persist the last used unix ms
+func (l *lease) renew(ctx context.Context, lastTs int64) error {
+	expireAt, err := l.store.RenewWorker(ctx, l.workerId, l.owner, l.ttl, lastTs)
+	if err != nil {
+		if err == dao.ErrLeaseLost {
+			l.expireAt.Store(0)
+		}
+		return err
+	}
+
+	l.expireAt.Store(expireAt)
+	return nil
+}
+
+func (l *lease) release(ctx context.Context, lastTs int64) {
+	l.expireAt.Store(0)
+	if err := l.store.ReleaseWorker(ctx, l.workerId, l.owner, lastTs); err != nil {
+		log.Printf("snowflake release worker id %d err: %v\n", l.workerId, err)
+		return
+	}
+	log.Printf("snowflake worker id %d released\n", l.workerId)
+}
+
+// owner identifies this node in lease table
+func leaseOwner() string {
+	host, _ := os.Hostname()
+	b := make([]byte, 4)
+	rand.Read(b)
+	return fmt.Sprintf("%s-%d-%s", host, os.Getpid(), hex.EncodeToString(b))
+}
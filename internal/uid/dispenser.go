@@ -0,0 +1,68 @@
+package uid
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ryanreadbooks/folium/internal/pkg"
+)
+
+// uid dispenses 128 bits time-ordered ids, uuidv7 and ulid, they are generated locally
+// and monotonic on this node, ids of different nodes are told apart by their random bits
+
+const (
+	maxBatchAllowed = 1000
+)
+
+var (
+	uuidGen = newGenerator(uuidRandBits)
+	ulidGen = newGenerator(ulidRandBits)
+)
+
+var (
+	ErrTimeOverflow = pkg.ErrInternal.Message("uid timestamp overflows 48 bits")
+)
+
+func nextBatch(g *generator, count uint32, format func(int64, uint64, uint64) string) ([]string, error) {
+	if count == 0 || count > maxBatchAllowed {
+		return nil, pkg.ErrInvalidArgs.Message(fmt.Sprintf("count should be in [1, %d]", maxBatchAllowed))
+	}
+
+	ids, err := g.nextN(int(count), format)
+	if err != nil {
+		if err == ErrTimeOverflow {
+			return nil, err
+		}
+		return nil, pkg.ErrInternal.Message(err.Error())
+	}
+
+	return ids, nil
+}
+
+// GetUUIDv7 returns the next uuidv7 in canonical form
+func GetUUIDv7(ctx context.Context) (string, error) {
+	ids, err := nextBatch(uuidGen, 1, formatUUIDv7)
+	if err != nil {
+		return "", err
+	}
+	return ids[0], nil
+}
+
+// GetUUIDv7Batch returns count uuidv7s in ascending order
+func GetUUIDv7Batch(ctx context.Context, count uint32) ([]string, error) {
+	return nextBatch(uuidGen, count, formatUUIDv7)
+}
+
+// GetULID returns the next ulid in crockford base32
+func GetULID(ctx context.Context) (string, error) {
+	ids, err := nextBatch(ulidGen, 1, formatULID)
+	if err != nil {
+		return "", err
+	}
+	return ids[0], nil
+}
+
+// GetULIDBatch returns count ulids in ascending order
+func GetULIDBatch(ctx context.Context, count uint32) ([]string, error) {
+	return nextBatch(ulidGen, count, formatULID)
+}
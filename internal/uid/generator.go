@@ -0,0 +1,137 @@
+package uid
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+const (
+	uuidRandBits = 74 // rand_a and rand_b of uuidv7
+	ulidRandBits = 80
+
+	maxTs = 1<<48 - 1 // both uuidv7 and ulid take 48 bits unix ms
+)
+
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// generator dispenses a 48 bits unix ms timestamp with randBits random bits,
+// the random bits are incremented within the same millisecond so that ids of this node are monotonic
+type generator struct {
+	sync.Mutex
+
+	randBits uint
+	lastTs   int64
+	hi, lo   uint64 // random bits of the last id, hi holds randBits-64 bits
+
+	now  func() int64 // returns unix ms
+	read func([]byte) (int, error)
+}
+
+func newGenerator(randBits uint) *generator {
+	return &generator{
+		randBits: randBits,
+		now:      func() int64 { return time.Now().UnixMilli() },
+		read:     rand.Read,
+	}
+}
+
+func (g *generator) fill() error {
+	var b [16]byte
+	if _, err := g.read(b[:]); err != nil {
+		return err
+	}
+	g.hi = binary.BigEndian.Uint64(b[:8]) & (1<<(g.randBits-64) - 1)
+	g.lo = binary.BigEndian.Uint64(b[8:])
+	return nil
+}
+
+// next returns the timestamp and random bits of the next id
+func (g *generator) next() (int64, uint64, uint64, error) {
+	g.Lock()
+	defer g.Unlock()
+
+	ts := g.now()
+	if ts > g.lastTs {
+		if err := g.fill(); err != nil {
+			return 0, 0, 0, err
+		}
+		g.lastTs = ts
+		return g.lastTs, g.hi, g.lo, nil
+	}
+
+	// same millisecond or clock moves backwards, the last timestamp is kept and random bits are incremented
+	g.lo++
+	if g.lo == 0 {
+		g.hi++
+		if g.hi == 1<<(g.randBits-64) {
+			// random bits overflow, borrow the next millisecond
+			if err := g.fill(); err != nil {
+				return 0, 0, 0, err
+			}
+			g.lastTs++
+		}
+	}
+
+	return g.lastTs, g.hi, g.lo, nil
+}
+
+func (g *generator) nextN(n int, format func(int64, uint64, uint64) string) ([]string, error) {
+	ids := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		ts, hi, lo, err := g.next()
+		if err != nil {
+			return nil, err
+		}
+		if ts > maxTs {
+			return nil, ErrTimeOverflow
+		}
+		ids = append(ids, format(ts, hi, lo))
+	}
+	return ids, nil
+}
+
+// formatUUIDv7 lays out ts and 74 random bits as uuid version 7 and variant 10
+func formatUUIDv7(ts int64, hi, lo uint64) string {
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], uint64(ts)<<16)
+	randA := hi<<2 | lo>>62 // 12 bits
+	b[6] = 0x70 | byte(randA>>8)
+	b[7] = byte(randA)
+	binary.BigEndian.PutUint64(b[8:], lo&(1<<62-1)|1<<63)
+
+	var s [36]byte
+	hex.Encode(s[0:8], b[0:4])
+	s[8] = '-'
+	hex.Encode(s[9:13], b[4:6])
+	s[13] = '-'
+	hex.Encode(s[14:18], b[6:8])
+	s[18] = '-'
+	hex.Encode(s[19:23], b[8:10])
+	s[23] = '-'
+	hex.Encode(s[24:], b[10:])
+	return string(s[:])
+}
+
+// formatULID encodes ts and 80 random bits in crockford base32
+func formatULID(ts int64, hi, lo uint64) string {
+	// 128 bits value is encoded in 26 characters of 5 bits from the top
+	h := uint64(ts)<<16 | hi
+	var s [26]byte
+	for i := range s {
+		shift := uint(125 - 5*i)
+		var v uint64
+		switch {
+		case shift >= 64:
+			v = h >> (shift - 64)
+		case shift == 0:
+			v = lo
+		default:
+			v = lo>>shift | h<<(64-shift)
+		}
+		s[i] = crockford[v&31]
+	}
+	return string(s[:])
+}
@@ -0,0 +1,90 @@
+package uid
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ryanreadbooks/folium/internal/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	ctx = context.Background()
+)
+
+func TestFormatUUIDv7(t *testing.T) {
+	// example of rfc 9562
+	id := formatUUIDv7(0x017F22E279B0, 0xCC3>>2, 3<<62|0x18C4DC0C0C07398F)
+	assert.Equal(t, "017f22e2-79b0-7cc3-98c4-dc0c0c07398f", id)
+}
+
+func TestFormatULID(t *testing.T) {
+	assert.Equal(t, "01ARYZ6S410000000000000000", formatULID(1469918176385, 0, 0))
+	assert.Equal(t, "7ZZZZZZZZZZZZZZZZZZZZZZZZZ", formatULID(maxTs, 1<<16-1, 1<<64-1))
+}
+
+func TestGenerator_next(t *testing.T) {
+	var now int64 = 1760000000000
+	g := newGenerator(uuidRandBits)
+	g.now = func() int64 { return now }
+
+	ids, err := g.nextN(1000, formatUUIDv7)
+	assert.Nil(t, err)
+	assert.True(t, sort.StringsAreSorted(ids))
+	for i := 1; i < len(ids); i++ {
+		assert.NotEqual(t, ids[i-1], ids[i])
+		// the timestamp stays the same within the millisecond
+		assert.Equal(t, ids[0][:13], ids[i][:13])
+	}
+
+	// clock moves backwards
+	now -= 10
+	more, err := g.nextN(10, formatUUIDv7)
+	assert.Nil(t, err)
+	assert.Less(t, ids[len(ids)-1], more[0])
+
+	// random bits overflow
+	g.hi, g.lo = 1<<(uuidRandBits-64)-1, 1<<64-1
+	ts, _, _, err := g.next()
+	assert.Nil(t, err)
+	assert.Equal(t, now+11, ts)
+}
+
+func TestGetULIDBatch(t *testing.T) {
+	_, err := GetULIDBatch(ctx, 0)
+	assert.Equal(t, pkg.ErrInvalidArgs.Code, err.(*pkg.Err).Code)
+	_, err = GetUUIDv7Batch(ctx, maxBatchAllowed+1)
+	assert.Equal(t, pkg.ErrInvalidArgs.Code, err.(*pkg.Err).Code)
+
+	var (
+		wg  sync.WaitGroup
+		mu  sync.Mutex
+		all = make(map[string]struct{})
+	)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ids, err := GetULIDBatch(ctx, 500)
+			assert.Nil(t, err)
+			assert.True(t, sort.StringsAreSorted(ids))
+			mu.Lock()
+			for _, id := range ids {
+				assert.Len(t, id, 26)
+				all[id] = struct{}{}
+			}
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, all, 4000)
+
+	id, err := GetUUIDv7(ctx)
+	assert.Nil(t, err)
+	assert.Len(t, id, 36)
+	assert.Equal(t, "7", id[14:15])
+	assert.True(t, strings.ContainsAny(id[19:20], "89ab"))
+}
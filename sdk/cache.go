@@ -0,0 +1,151 @@
+package sdk
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	defaultCacheSize      uint32 = 1000
+	defaultCacheWatermark        = 0.2
+	leaseTimeout                 = time.Second * 3
+)
+
+// localSegment holds ids in [begin, end)
+type localSegment struct {
+	begin uint64
+	cur   uint64
+	end   uint64
+}
+
+func (s *localSegment) available() bool {
+	return s.cur < s.end
+}
+
+// ratio of consumed ids in segment
+func (s *localSegment) consumed() float64 {
+	return float64(s.cur-s.begin) / float64(s.end-s.begin)
+}
+
+// localBuffer is the double buffer for one key, ids are served from cur
+// while next is prefetched asynchronously
+type localBuffer struct {
+	sync.Mutex
+
+	key  string
+	cur  *localSegment
+	next *localSegment // nil if not prefetched yet
+
+	loading bool
+	loadErr error
+	loaded  chan struct{} // closed when loading is done
+}
+
+// segmentCache leases ranges from folium and serves ids locally
+type segmentCache struct {
+	impl      Impl
+	size      uint32
+	watermark float64
+
+	bufs sync.Map // key -> *localBuffer
+}
+
+func newSegmentCache(impl Impl, size uint32, watermark float64) *segmentCache {
+	if size == 0 {
+		size = defaultCacheSize
+	}
+	if watermark <= 0 || watermark >= 1 {
+		watermark = defaultCacheWatermark
+	}
+
+	return &segmentCache{
+		impl:      impl,
+		size:      size,
+		watermark: watermark,
+	}
+}
+
+func (c *segmentCache) getId(ctx context.Context, key string) (uint64, error) {
+	val, _ := c.bufs.LoadOrStore(key, &localBuffer{key: key})
+	buf := val.(*localBuffer)
+
+	buf.Lock()
+	for {
+		if buf.cur != nil && buf.cur.available() {
+			id := buf.cur.cur
+			buf.cur.cur++
+			// prefetch the next segment when watermark is reached
+			if buf.next == nil && !buf.loading && buf.cur.consumed() >= c.watermark {
+				c.load(buf)
+			}
+			buf.Unlock()
+			return id, nil
+		}
+
+		// current segment is used up
+		if buf.next != nil {
+			buf.cur, buf.next = buf.next, nil
+			continue
+		}
+
+		if !buf.loading {
+			c.load(buf)
+		}
+		loaded := buf.loaded
+		buf.Unlock()
+
+		select {
+		case <-loaded:
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+
+		buf.Lock()
+		if buf.next == nil && buf.loadErr != nil {
+			err := buf.loadErr
+			buf.loadErr = nil
+			buf.Unlock()
+			return 0, err
+		}
+	}
+}
+
+func (c *segmentCache) getIds(ctx context.Context, key string, count uint32) ([]uint64, error) {
+	ids := make([]uint64, 0, count)
+	for i := uint32(0); i < count; i++ {
+		id, err := c.getId(ctx, key)
+		if err != nil {
+			if len(ids) != 0 {
+				return ids, nil
+			}
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// load leases the next segment in the background, buf should be locked
+func (c *segmentCache) load(buf *localBuffer) {
+	buf.loading = true
+	buf.loadErr = nil
+	buf.loaded = make(chan struct{})
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), leaseTimeout)
+		begin, end, err := c.impl.LeaseRange(ctx, buf.key, c.size)
+		cancel()
+
+		buf.Lock()
+		defer buf.Unlock()
+		if err != nil {
+			buf.loadErr = err
+		} else {
+			buf.next = &localSegment{begin: begin, cur: begin, end: end}
+		}
+		buf.loading = false
+		close(buf.loaded)
+	}()
+}
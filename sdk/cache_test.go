@@ -0,0 +1,130 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ryanreadbooks/folium/internal/pkg/misc"
+	"github.com/stretchr/testify/assert"
+)
+
+// rangeImpl hands out ranges from a local counter
+type rangeImpl struct {
+	sync.Mutex
+
+	next   uint64
+	leases atomic.Int32
+	delay  time.Duration
+	err    error
+}
+
+func (r *rangeImpl) Next(ctx context.Context, key string, step uint32) (uint64, error) {
+	return 0, errors.New("not supported")
+}
+
+func (r *rangeImpl) NextBatch(ctx context.Context, key string, count uint32, step uint32) ([]uint64, error) {
+	return nil, errors.New("not supported")
+}
+
+func (r *rangeImpl) NextSnowflake(ctx context.Context) (uint64, error) {
+	return 0, errors.New("not supported")
+}
+
+func (r *rangeImpl) LeaseRange(ctx context.Context, key string, size uint32) (uint64, uint64, error) {
+	time.Sleep(r.delay)
+	r.Lock()
+	defer r.Unlock()
+	if r.err != nil {
+		return 0, 0, r.err
+	}
+	r.leases.Add(1)
+	begin := r.next + 1
+	r.next += uint64(size)
+	return begin, begin + uint64(size), nil
+}
+
+func (r *rangeImpl) NextUUIDv7(ctx context.Context) (string, error) {
+	return "", errors.New("not supported")
+}
+
+func (r *rangeImpl) NextUUIDv7Batch(ctx context.Context, count uint32) ([]string, error) {
+	return nil, errors.New("not supported")
+}
+
+func (r *rangeImpl) NextULID(ctx context.Context) (string, error) {
+	return "", errors.New("not supported")
+}
+
+func (r *rangeImpl) NextULIDBatch(ctx context.Context, count uint32) ([]string, error) {
+	return nil, errors.New("not supported")
+}
+
+func (r *rangeImpl) Ping(ctx context.Context) error {
+	return nil
+}
+
+func TestCache_getId(t *testing.T) {
+	impl := &rangeImpl{}
+	cache := newSegmentCache(impl, 10, 0.5)
+
+	for i := 1; i <= 35; i++ {
+		id, err := cache.getId(ctx, "test-biz")
+		assert.Nil(t, err)
+		assert.EqualValues(t, i, id)
+	}
+
+	// prefetched in the background
+	time.Sleep(time.Millisecond * 10)
+	assert.EqualValues(t, 5, impl.leases.Load())
+}
+
+func TestCache_concurrentGetId(t *testing.T) {
+	impl := &rangeImpl{delay: time.Millisecond}
+	cache := newSegmentCache(impl, 100, 0.2)
+
+	var (
+		wg   sync.WaitGroup
+		lock sync.Mutex
+		num  = 50
+		per  = 200
+	)
+
+	ids := make([]uint64, 0, num*per)
+	for i := 0; i < num; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < per; j++ {
+				id, err := cache.getId(ctx, "test-biz")
+				assert.Nil(t, err)
+				lock.Lock()
+				ids = append(ids, id)
+				lock.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+	assert.Len(t, ids, num*per)
+	assert.EqualValues(t, false, misc.HasDupElems(ids))
+}
+
+func TestCache_leaseErr(t *testing.T) {
+	impl := &rangeImpl{err: errors.New("lease failed")}
+	cache := newSegmentCache(impl, 10, 0.5)
+
+	_, err := cache.getId(ctx, "test-biz")
+	assert.NotNil(t, err)
+
+	impl.Lock()
+	impl.err = nil
+	impl.Unlock()
+
+	ids, err := cache.getIds(ctx, "test-biz", 3)
+	assert.Nil(t, err)
+	assert.EqualValues(t, []uint64{1, 2, 3}, ids)
+}
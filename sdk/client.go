@@ -7,6 +7,12 @@ import (
 
 type IClient interface {
 	GetId(ctx context.Context, key string, step uint32) (uint64, error)
+	GetIds(ctx context.Context, key string, count uint32, step uint32) ([]uint64, error)
+	GetSnowflakeId(ctx context.Context) (uint64, error)
+	GetUUIDv7(ctx context.Context) (string, error)
+	GetUUIDv7s(ctx context.Context, count uint32) ([]string, error)
+	GetULID(ctx context.Context) (string, error)
+	GetULIDs(ctx context.Context, count uint32) ([]string, error)
 	Ping(ctx context.Context) error
 }
 
@@ -15,6 +21,12 @@ type Client struct {
 	isGrpc bool
 
 	impl Impl
+
+	// ids are served from local segments when cache is enabled
+	useCache       bool
+	cacheSize      uint32
+	cacheWatermark float64
+	cache          *segmentCache
 }
 
 var _ IClient = (*Client)(nil)
@@ -23,6 +35,10 @@ type opt struct {
 	http      string
 	grpc      string
 	downgrade bool
+
+	cache          bool
+	cacheSize      uint32
+	cacheWatermark float64
 }
 
 type Option func(o *opt)
@@ -45,6 +61,16 @@ func WithDowngrade() Option {
 	}
 }
 
+// WithCacheOpt enables local segment cache, size is the number of ids leased from server every time,
+// the next segment is prefetched when the consumed ratio of current segment reaches watermark
+func WithCacheOpt(size uint32, watermark float64) Option {
+	return func(o *opt) {
+		o.cache = true
+		o.cacheSize = size
+		o.cacheWatermark = watermark
+	}
+}
+
 func New(opts ...Option) (IClient, error) {
 	opt := &opt{}
 	for _, o := range opts {
@@ -58,6 +84,9 @@ func New(opts ...Option) (IClient, error) {
 	if opt.grpc != "" {
 		clientOpts = append(clientOpts, WithGrpc(opt.grpc))
 	}
+	if opt.cache {
+		clientOpts = append(clientOpts, WithCache(opt.cacheSize, opt.cacheWatermark))
+	}
 
 	c, err := NewClient(clientOpts...)
 	if err != nil {
@@ -72,6 +101,17 @@ func New(opts ...Option) (IClient, error) {
 
 type ClientOpt func(*Client) error
 
+// WithCache serves ids from local segments leased from server instead of calling server for every id,
+// step is ignored when getting ids in this mode
+func WithCache(size uint32, watermark float64) ClientOpt {
+	return func(c *Client) error {
+		c.useCache = true
+		c.cacheSize = size
+		c.cacheWatermark = watermark
+		return nil
+	}
+}
+
 func NewClient(opts ...ClientOpt) (*Client, error) {
 	c := &Client{}
 
@@ -87,18 +127,62 @@ func NewClient(opts ...ClientOpt) (*Client, error) {
 		return nil, fmt.Errorf("sdk client is either http client or grpc client, can not be both")
 	}
 
+	if c.useCache {
+		if c.impl == nil {
+			return nil, fmt.Errorf("sdk client cache needs http client or grpc client")
+		}
+		c.cache = newSegmentCache(c.impl, c.cacheSize, c.cacheWatermark)
+	}
+
 	return c, nil
 }
 
 type Impl interface {
 	Next(ctx context.Context, key string, step uint32) (uint64, error)
+	NextBatch(ctx context.Context, key string, count uint32, step uint32) ([]uint64, error)
+	NextSnowflake(ctx context.Context) (uint64, error)
+	LeaseRange(ctx context.Context, key string, size uint32) (uint64, uint64, error)
+	NextUUIDv7(ctx context.Context) (string, error)
+	NextUUIDv7Batch(ctx context.Context, count uint32) ([]string, error)
+	NextULID(ctx context.Context) (string, error)
+	NextULIDBatch(ctx context.Context, count uint32) ([]string, error)
 	Ping(ctx context.Context) error
 }
 
 func (c *Client) GetId(ctx context.Context, key string, step uint32) (uint64, error) {
+	if c.cache != nil {
+		return c.cache.getId(ctx, key)
+	}
 	return c.impl.Next(ctx, key, step)
 }
 
+func (c *Client) GetIds(ctx context.Context, key string, count uint32, step uint32) ([]uint64, error) {
+	if c.cache != nil {
+		return c.cache.getIds(ctx, key, count)
+	}
+	return c.impl.NextBatch(ctx, key, count, step)
+}
+
+func (c *Client) GetSnowflakeId(ctx context.Context) (uint64, error) {
+	return c.impl.NextSnowflake(ctx)
+}
+
+func (c *Client) GetUUIDv7(ctx context.Context) (string, error) {
+	return c.impl.NextUUIDv7(ctx)
+}
+
+func (c *Client) GetUUIDv7s(ctx context.Context, count uint32) ([]string, error) {
+	return c.impl.NextUUIDv7Batch(ctx, count)
+}
+
+func (c *Client) GetULID(ctx context.Context) (string, error) {
+	return c.impl.NextULID(ctx)
+}
+
+func (c *Client) GetULIDs(ctx context.Context, count uint32) ([]string, error) {
+	return c.impl.NextULIDBatch(ctx, count)
+}
+
 func (c *Client) Ping(ctx context.Context) error {
 	return c.impl.Ping(ctx)
 }
@@ -2,7 +2,13 @@ package sdk
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
 )
 
 var (
@@ -48,4 +54,78 @@ func TestClient_DowngradeClient(t *testing.T) {
 	}
 
 	t.Logf("id = %d\n", id)
-}
\ No newline at end of file
+}
+
+func TestClient_GrpcSnowflake(t *testing.T) {
+	cli, err := New(WithGrpcOpt("localhost:9528"))
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	id, err := cli.GetSnowflakeId(ctx)
+	if err != nil {
+		t.Logf("err = %v\n", err)
+		return
+	}
+
+	t.Logf("id = %d\n", id)
+}
+
+func TestClient_GrpcBatch(t *testing.T) {
+	cli, err := New(WithGrpcOpt("localhost:9528"))
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	ids, err := cli.GetIds(ctx, "test-biz", 10, 0)
+	if err != nil {
+		t.Logf("err = %v\n", err)
+		return
+	}
+
+	t.Logf("ids = %v\n", ids)
+}
+
+func TestClient_GrpcUUIDv7(t *testing.T) {
+	cli, err := New(WithGrpcOpt("localhost:9528"))
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	ids, err := cli.GetUUIDv7s(ctx, 10)
+	if err != nil {
+		t.Logf("err = %v\n", err)
+		return
+	}
+
+	t.Logf("uuids = %v\n", ids)
+}
+
+func TestClient_HttpULID(t *testing.T) {
+	cli, _ := NewClient(WithHttp("localhost:9527"))
+	id, err := cli.GetULID(ctx)
+	if err != nil {
+		t.Logf("err = %v\n", err)
+		return
+	}
+
+	t.Logf("ulid = %s\n", id)
+}
+
+func TestClient_HttpLeaseTimeout(t *testing.T) {
+	hang := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-hang
+	}))
+	defer srv.Close()
+	defer close(hang)
+
+	c := &httpClient{c: &http.Client{}, addr: strings.TrimPrefix(srv.URL, "http://")}
+	tctx, cancel := context.WithTimeout(ctx, time.Millisecond*100)
+	defer cancel()
+
+	start := time.Now()
+	_, _, err := c.LeaseRange(tctx, "test-biz", 100)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
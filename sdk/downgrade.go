@@ -11,6 +11,30 @@ func (c *downGradedClient) GetId(ctx context.Context, key string, step uint32) (
 	return 0, ErrFoliumNotConnected
 }
 
+func (c *downGradedClient) GetIds(ctx context.Context, key string, count uint32, step uint32) ([]uint64, error) {
+	return nil, ErrFoliumNotConnected
+}
+
+func (c *downGradedClient) GetSnowflakeId(ctx context.Context) (uint64, error) {
+	return 0, ErrFoliumNotConnected
+}
+
+func (c *downGradedClient) GetUUIDv7(ctx context.Context) (string, error) {
+	return "", ErrFoliumNotConnected
+}
+
+func (c *downGradedClient) GetUUIDv7s(ctx context.Context, count uint32) ([]string, error) {
+	return nil, ErrFoliumNotConnected
+}
+
+func (c *downGradedClient) GetULID(ctx context.Context) (string, error) {
+	return "", ErrFoliumNotConnected
+}
+
+func (c *downGradedClient) GetULIDs(ctx context.Context, count uint32) ([]string, error) {
+	return nil, ErrFoliumNotConnected
+}
+
 func (c *downGradedClient) Ping(ctx context.Context) error {
 	return ErrFoliumNotConnected
 }
@@ -39,26 +39,87 @@ func (c *grpcClient) Next(ctx context.Context, key string, step uint32) (uint64,
 
 	resp, err := c.cli.Next(ctx, req)
 	if err != nil {
-		grpcerr, ok := status.FromError(err)
-		if ok {
-			var baseErr error
-			switch grpcerr.Code() {
-			case codes.InvalidArgument:
-				baseErr = ErrWrongRequestFormat
-			case codes.Internal:
-				baseErr = ErrFolium
-			default:
-				baseErr = ErrGetIdFailed
-			}
-
-			return 0, fmt.Errorf("next err %v: %v", baseErr, grpcerr.Message())
-		}
-		return 0, err
+		return 0, wrapGrpcErr("next", err)
+	}
+
+	return resp.Id, nil
+}
+
+func (c *grpcClient) NextBatch(ctx context.Context, key string, count uint32, step uint32) ([]uint64, error) {
+	req := &apiv1.NextBatchRequest{
+		Key:   key,
+		Count: count,
+		Step:  step,
+	}
+
+	resp, err := c.cli.NextBatch(ctx, req)
+	if err != nil {
+		return nil, wrapGrpcErr("next batch", err)
+	}
+
+	return resp.Ids, nil
+}
+
+// LeaseRange returns a range [begin, end) of ids for key
+func (c *grpcClient) LeaseRange(ctx context.Context, key string, size uint32) (uint64, uint64, error) {
+	req := &apiv1.LeaseRangeRequest{
+		Key:  key,
+		Size: size,
+	}
+
+	resp, err := c.cli.LeaseRange(ctx, req)
+	if err != nil {
+		return 0, 0, wrapGrpcErr("lease range", err)
+	}
+
+	return resp.Begin, resp.End, nil
+}
+
+func (c *grpcClient) NextSnowflake(ctx context.Context) (uint64, error) {
+	resp, err := c.cli.NextSnowflake(ctx, &apiv1.NextSnowflakeRequest{})
+	if err != nil {
+		return 0, wrapGrpcErr("next snowflake", err)
 	}
 
 	return resp.Id, nil
 }
 
+func (c *grpcClient) NextUUIDv7(ctx context.Context) (string, error) {
+	resp, err := c.cli.NextUUIDv7(ctx, &apiv1.NextUUIDv7Request{})
+	if err != nil {
+		return "", wrapGrpcErr("next uuidv7", err)
+	}
+
+	return resp.Uuid, nil
+}
+
+func (c *grpcClient) NextUUIDv7Batch(ctx context.Context, count uint32) ([]string, error) {
+	resp, err := c.cli.NextUUIDv7Batch(ctx, &apiv1.NextUUIDv7BatchRequest{Count: count})
+	if err != nil {
+		return nil, wrapGrpcErr("next uuidv7 batch", err)
+	}
+
+	return resp.Uuids, nil
+}
+
+func (c *grpcClient) NextULID(ctx context.Context) (string, error) {
+	resp, err := c.cli.NextULID(ctx, &apiv1.NextULIDRequest{})
+	if err != nil {
+		return "", wrapGrpcErr("next ulid", err)
+	}
+
+	return resp.Ulid, nil
+}
+
+func (c *grpcClient) NextULIDBatch(ctx context.Context, count uint32) ([]string, error) {
+	resp, err := c.cli.NextULIDBatch(ctx, &apiv1.NextULIDBatchRequest{Count: count})
+	if err != nil {
+		return nil, wrapGrpcErr("next ulid batch", err)
+	}
+
+	return resp.Ulids, nil
+}
+
 func (c *grpcClient) Ping(ctx context.Context) error {
 	_, err := c.cli.Ping(ctx, &apiv1.PingRequest{})
 	if err != nil {
@@ -70,3 +131,21 @@ func (c *grpcClient) Ping(ctx context.Context) error {
 
 	return nil
 }
+
+func wrapGrpcErr(op string, err error) error {
+	grpcerr, ok := status.FromError(err)
+	if ok {
+		var baseErr error
+		switch grpcerr.Code() {
+		case codes.InvalidArgument:
+			baseErr = ErrWrongRequestFormat
+		case codes.Internal:
+			baseErr = ErrFolium
+		default:
+			baseErr = ErrGetIdFailed
+		}
+
+		return fmt.Errorf("%s err %v: %v", op, baseErr, grpcerr.Message())
+	}
+	return err
+}
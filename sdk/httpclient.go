@@ -1,16 +1,23 @@
 package sdk
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/ryanreadbooks/folium/internal/pkg"
 	"github.com/ryanreadbooks/folium/internal/segment/server"
 )
 
+const (
+	// upper bound of a request whose ctx has no deadline
+	defaultHttpTimeout = time.Second * 10
+)
+
 type httpClient struct {
 	c    *http.Client
 	addr string
@@ -20,7 +27,7 @@ func WithHttp(addr string) ClientOpt {
 	return func(c *Client) error {
 		c.isHttp = true
 		c.impl = &httpClient{
-			c:    &http.Client{},
+			c:    &http.Client{Timeout: defaultHttpTimeout},
 			addr: addr,
 		}
 
@@ -34,45 +41,180 @@ func (c *httpClient) Next(ctx context.Context, key string, step uint32) (uint64,
 		path = fmt.Sprintf("%s?step=%d", path, step)
 	}
 
-	resp, err := c.c.Get(path)
+	return c.getId(ctx, path)
+}
+
+func (c *httpClient) NextSnowflake(ctx context.Context) (uint64, error) {
+	path := fmt.Sprintf("http://%s/api/v1/snowflake", c.addr)
+	return c.getId(ctx, path)
+}
+
+func (c *httpClient) NextBatch(ctx context.Context, key string, count uint32, step uint32) ([]uint64, error) {
+	path := fmt.Sprintf("http://%s/api/v1/next/%s/batch", c.addr, key)
+	result, err := c.postBatch(ctx, path, count, step, func(r *server.Result) bool { return len(r.Ids) != 0 })
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Ids, nil
+}
+
+func (c *httpClient) NextUUIDv7(ctx context.Context) (string, error) {
+	path := fmt.Sprintf("http://%s/api/v1/uuidv7", c.addr)
+	return c.getUid(ctx, path)
+}
+
+func (c *httpClient) NextUUIDv7Batch(ctx context.Context, count uint32) ([]string, error) {
+	path := fmt.Sprintf("http://%s/api/v1/uuidv7/batch", c.addr)
+	return c.getUids(ctx, path, count)
+}
+
+func (c *httpClient) NextULID(ctx context.Context) (string, error) {
+	path := fmt.Sprintf("http://%s/api/v1/ulid", c.addr)
+	return c.getUid(ctx, path)
+}
+
+func (c *httpClient) NextULIDBatch(ctx context.Context, count uint32) ([]string, error) {
+	path := fmt.Sprintf("http://%s/api/v1/ulid/batch", c.addr)
+	return c.getUids(ctx, path, count)
+}
+
+func (c *httpClient) postBatch(ctx context.Context, path string, count, step uint32, ok func(*server.Result) bool) (*server.Result, error) {
+	body, err := json.Marshal(&server.BatchReq{
+		Count: count,
+		Step:  step,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.post(ctx, path, body)
+	if err != nil {
+		// network error
+		return nil, err
+	}
+
+	return parseResult(resp, ok)
+}
+
+// LeaseRange returns a range [begin, end) of ids for key
+func (c *httpClient) LeaseRange(ctx context.Context, key string, size uint32) (uint64, uint64, error) {
+	path := fmt.Sprintf("http://%s/api/v1/lease/%s", c.addr, key)
+	body, err := json.Marshal(&server.LeaseReq{
+		Size: size,
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	resp, err := c.post(ctx, path, body)
+	if err != nil {
+		// network error
+		return 0, 0, err
+	}
+
+	result, err := parseResult(resp, func(r *server.Result) bool { return r.End != 0 })
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return result.Begin, result.End, nil
+}
+
+func (c *httpClient) getId(ctx context.Context, path string) (uint64, error) {
+	resp, err := c.get(ctx, path)
 	if err != nil {
 		// network error
 		return 0, err
 	}
 
+	result, err := parseResult(resp, func(r *server.Result) bool { return r.Id != 0 })
+	if err != nil {
+		return 0, err
+	}
+
+	return result.Id, nil
+}
+
+func (c *httpClient) getUid(ctx context.Context, path string) (string, error) {
+	resp, err := c.get(ctx, path)
+	if err != nil {
+		// network error
+		return "", err
+	}
+
+	result, err := parseResult(resp, func(r *server.Result) bool { return r.Uid != "" })
+	if err != nil {
+		return "", err
+	}
+
+	return result.Uid, nil
+}
+
+func (c *httpClient) getUids(ctx context.Context, path string, count uint32) ([]string, error) {
+	result, err := c.postBatch(ctx, path, count, 0, func(r *server.Result) bool { return len(r.Uids) != 0 })
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Uids, nil
+}
+
+// get and post are bound to ctx so that they are cancelled once ctx is done
+func (c *httpClient) get(ctx context.Context, path string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.c.Do(req)
+}
+
+func (c *httpClient) post(ctx context.Context, path string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return c.c.Do(req)
+}
+
+// parseResult reads result from resp, ok reports whether the result carries data
+func parseResult(resp *http.Response, ok func(*server.Result) bool) (*server.Result, error) {
 	// resp contains the result of the request
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	var result server.Result
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return 0, fmt.Errorf("%v: %v: statuscode: %d", ErrResultNotRecognized, err, resp.StatusCode)
+		return nil, fmt.Errorf("%v: %v: statuscode: %d", ErrResultNotRecognized, err, resp.StatusCode)
 	}
 
-	if result.Id == 0 {
+	if !ok(&result) {
 		// folium error occur
 		var errMsg pkg.Err
 		err = json.Unmarshal([]byte(result.Msg), &errMsg)
 		if err != nil {
-			return 0, fmt.Errorf("%v: %v: statuscode: %d", ErrResultNotRecognized, err, resp.StatusCode)
+			return nil, fmt.Errorf("%v: %v: statuscode: %d", ErrResultNotRecognized, err, resp.StatusCode)
 		}
-		return 0, errMsg
+		return nil, errMsg
 	}
 
-	return result.Id, nil
+	return &result, nil
 }
 
 func (c *httpClient) Ping(ctx context.Context) error {
 	path := fmt.Sprintf("http://%s/api/v1/health", c.addr)
-	resp, err := c.c.Get(path)
+	resp, err := c.get(ctx, path)
 	if err != nil {
 		return err
 	}
 
+	resp.Body.Close()
+
 	if resp.StatusCode != http.StatusOK {
 		return fmt.Errorf("ping err: statuscode: %d", resp.StatusCode)
 	}